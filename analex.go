@@ -4,30 +4,62 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"iter"
 	"log"
+	"maps"
 	"math/big"
 	"os"
-	"regexp"
+	"slices"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
-	"golang.org/x/text/runes"
-	"golang.org/x/text/transform"
 	"golang.org/x/text/unicode/norm"
 )
 
 type TokenType int
 
 type Token struct {
-	Type   TokenType
-	Value  string
-	Spell  string
+	Type  TokenType
+	Value string
+	Spell string
+	// Number is the value of an integer TOKEN_NUMBER_PARSED token and Rat the
+	// value of any of them, decimals included
 	Number *big.Int
+	Rat    *big.Rat
+	// Currency is the code of the currency of a money amount, as in "dez
+	// reais", and empty for other numbers
+	Currency string
+	// Pos is where the token starts and End where it stops (exclusive). A
+	// number merged from several words spans all of them.
+	Pos Position
+	End Position
+	// Err describes the failure of a TOKEN_ERROR token
+	Err *LexError
+}
+
+// Position locates a lexeme in the original, not normalised, input.
+type Position struct {
+	Offset int // byte offset in the line
+	Line   int // line number, starting at 1
+	Column int // column in runes, starting at 1
+	Word   int // word index in the line, starting at 0
+}
+
+type word struct {
+	lexeme string
+	pos    Position
+	end    Position
+	// class and val are the class and value of the lexeme, set by
+	// classifyWords
+	class wordClass
+	val   numberState
 }
 
 const (
 	TOKEN_ERROR TokenType = iota
 	TOKEN_EOF
+	TOKEN_EOL
 
 	TOKEN_LEFT_BRACKET
 	TOKEN_RIGHT_BRACKET
@@ -45,97 +77,248 @@ const (
 )
 
 type Lexer struct {
-	scannerStdIn *bufio.Reader
-	numberDict   map[string]numberState
-	verbose      bool
+	reader *bufio.Reader
+	// numberDict is the vocabulary of the locale, shared with other lexers,
+	// and localDict the words of this lexer only, such as the currency units
+	numberDict map[string]numberState
+	localDict  map[string]numberState
+	verbose    bool
+	recovery   bool
+	symbols    bool
+	locale     Locale
+	currency   Currency
+	line       int
+}
+
+// symbolOperators maps the operator symbols accepted by SetSymbols to their
+// token type. "x" only stands for times as a word of its own.
+var symbolOperators = map[string]TokenType{
+	"+": TOKEN_PLUS,
+	"-": TOKEN_MINUS,
+	"*": TOKEN_TIMES,
+	"/": TOKEN_DIVIDE,
+	"^": TOKEN_POWER,
+	"%": TOKEN_MOD,
+	"!": TOKEN_FACTORIAL,
+	"(": TOKEN_LEFT_BRACKET,
+	")": TOKEN_RIGHT_BRACKET,
 }
 
 type numberState struct {
-	state int
-	value string
+	class  wordClass
+	value  string
+	gender Gender
+}
+
+// ordinalWords lists the masculine ordinals. Their feminine forms, ending in
+// "a", are read the same, and their plurals, but for "primeiros", "segundos"
+// and "terceiros", are the fraction words of "tres quartos".
+var ordinalWords = map[string]numberState{
+	"primeiro":         {class: classOrdinalFirst, value: "1"},
+	"segundo":          {class: classOrdinalFirst, value: "2"},
+	"terceiro":         {class: classOrdinalFirst, value: "3"},
+	"quarto":           {class: classOrdinalUnit, value: "4"},
+	"quinto":           {class: classOrdinalUnit, value: "5"},
+	"sexto":            {class: classOrdinalUnit, value: "6"},
+	"setimo":           {class: classOrdinalUnit, value: "7"},
+	"oitavo":           {class: classOrdinalUnit, value: "8"},
+	"nono":             {class: classOrdinalUnit, value: "9"},
+	"decimo":           {class: classOrdinalTen, value: "10"},
+	"vigesimo":         {class: classOrdinalTen, value: "20"},
+	"trigesimo":        {class: classOrdinalTen, value: "30"},
+	"quadragesimo":     {class: classOrdinalTen, value: "40"},
+	"quinquagesimo":    {class: classOrdinalTen, value: "50"},
+	"sexagesimo":       {class: classOrdinalTen, value: "60"},
+	"septuagesimo":     {class: classOrdinalTen, value: "70"},
+	"octogesimo":       {class: classOrdinalTen, value: "80"},
+	"nonagesimo":       {class: classOrdinalTen, value: "90"},
+	"setuagesimo":      {class: classOrdinalTen, value: "70"},
+	"centesimo":        {class: classOrdinalHundred, value: "100"},
+	"ducentesimo":      {class: classOrdinalHundred, value: "200"},
+	"trecentesimo":     {class: classOrdinalHundred, value: "300"},
+	"quadringentesimo": {class: classOrdinalHundred, value: "400"},
+	"quingentesimo":    {class: classOrdinalHundred, value: "500"},
+	"sexcentesimo":     {class: classOrdinalHundred, value: "600"},
+	"septingentesimo":  {class: classOrdinalHundred, value: "700"},
+	"octingentesimo":   {class: classOrdinalHundred, value: "800"},
+	"nongentesimo":     {class: classOrdinalHundred, value: "900"},
+	"tricentesimo":     {class: classOrdinalHundred, value: "300"},
+	"seiscentesimo":    {class: classOrdinalHundred, value: "600"},
+	"setingentesimo":   {class: classOrdinalHundred, value: "700"},
+	"noningentesimo":   {class: classOrdinalHundred, value: "900"},
+	"milesimo":         {class: classOrdinalScale, value: "1000"},
+}
+
+// numberWords is the dictionary of the number words that do not depend on the
+// locale nor on the currency.
+var numberWords = map[string]numberState{
+	"um":           {class: classUnit, value: "1"},
+	"dois":         {class: classUnit, value: "2"},
+	"uma":          {class: classUnit, value: "1", gender: Feminine},
+	"duas":         {class: classUnit, value: "2", gender: Feminine},
+	"tres":         {class: classUnit, value: "3"},
+	"quatro":       {class: classUnit, value: "4"},
+	"cinco":        {class: classUnit, value: "5"},
+	"seis":         {class: classUnit, value: "6"},
+	"sete":         {class: classUnit, value: "7"},
+	"oito":         {class: classUnit, value: "8"},
+	"nove":         {class: classUnit, value: "9"},
+	"dez":          {class: classUnit, value: "10"},
+	"onze":         {class: classUnit, value: "11"},
+	"doze":         {class: classUnit, value: "12"},
+	"treze":        {class: classUnit, value: "13"},
+	"quatorze":     {class: classUnit, value: "14"},
+	"catorze":      {class: classUnit, value: "14"},
+	"quinze":       {class: classUnit, value: "15"},
+	"dezesseis":    {class: classUnit, value: "16"},
+	"dezessete":    {class: classUnit, value: "17"},
+	"dezoito":      {class: classUnit, value: "18"},
+	"dezenove":     {class: classUnit, value: "19"},
+	"dezasseis":    {class: classUnit, value: "16"},
+	"dezassete":    {class: classUnit, value: "17"},
+	"dezanove":     {class: classUnit, value: "19"},
+	"vinte":        {class: classTen, value: "20"},
+	"trinta":       {class: classTen, value: "30"},
+	"quarenta":     {class: classTen, value: "40"},
+	"cinquenta":    {class: classTen, value: "50"},
+	"sessenta":     {class: classTen, value: "60"},
+	"setenta":      {class: classTen, value: "70"},
+	"oitenta":      {class: classTen, value: "80"},
+	"noventa":      {class: classTen, value: "90"},
+	"cem":          {class: classHundredExact, value: "100"},
+	"cento":        {class: classCento, value: "100"},
+	"duzentos":     {class: classHundred, value: "200"},
+	"trezentos":    {class: classHundred, value: "300"},
+	"quatrocentos": {class: classHundred, value: "400"},
+	"quinhentos":   {class: classHundred, value: "500"},
+	"seiscentos":   {class: classHundred, value: "600"},
+	"setecentos":   {class: classHundred, value: "700"},
+	"oitocentos":   {class: classHundred, value: "800"},
+	"novecentos":   {class: classHundred, value: "900"},
+	"duzentas":     {class: classHundred, value: "200", gender: Feminine},
+	"trezentas":    {class: classHundred, value: "300", gender: Feminine},
+	"quatrocentas": {class: classHundred, value: "400", gender: Feminine},
+	"quinhentas":   {class: classHundred, value: "500", gender: Feminine},
+	"seiscentas":   {class: classHundred, value: "600", gender: Feminine},
+	"setecentas":   {class: classHundred, value: "700", gender: Feminine},
+	"oitocentas":   {class: classHundred, value: "800", gender: Feminine},
+	"novecentas":   {class: classHundred, value: "900", gender: Feminine},
+	"mil":          {class: classThousand, value: "1000"},
+	"zero":         {class: classZero, value: "0"},
+	"e":            {class: classAnd, value: "0"},
+	"virgula":      {class: classComma, value: "0"},
+	"meio":         {class: classHalf, value: "1/2"},
+	"meios":        {class: classFraction, value: "2"},
+	"terco":        {class: classFraction, value: "3"},
+	"tercos":       {class: classFraction, value: "3"},
+	"avos":         {class: classAvos, value: "0"},
+	"de":           {class: classOf, value: "0"},
+}
+
+// vocabularies holds the dictionary of the number words of each locale:
+// numberWords, the ordinals and the scale names of Scales. It is built once
+// and shared by every lexer, which never change it, and built again when
+// Scales is edited.
+var vocabularies [PtPT + 1]scalesCache[map[string]numberState]
+
+// vocabulary returns the shared dictionary of the number words of the locale.
+func (l Locale) vocabulary() map[string]numberState {
+	return vocabularies[l].get(func() map[string]numberState {
+		return newVocabulary(l)
+	})
+}
+
+func newVocabulary(locale Locale) map[string]numberState {
+	words := maps.Clone(numberWords)
+
+	for word, val := range ordinalWords {
+		addOrdinal(words, word, val)
+	}
+
+	for rank, scale := range Scales {
+		value := locale.scaleValue(rank).String()
+
+		for _, word := range scale.words() {
+			words[removeAccents(word)] = numberState{class: classScale, value: value}
+		}
+
+		for _, word := range scale.ordinals() {
+			addOrdinal(words, removeAccents(word), numberState{class: classOrdinalScale, value: value})
+		}
+	}
+
+	return words
+}
+
+// addOrdinal adds a masculine ordinal to words, along with its feminine form
+// and, as a fraction word, its plural.
+func addOrdinal(words map[string]numberState, word string, val numberState) {
+	feminine := strings.TrimSuffix(word, "o") + "a"
+
+	words[word] = val
+	words[feminine] = numberState{class: val.class, value: val.value, gender: Feminine}
+
+	if val.class != classOrdinalFirst {
+		words[word+"s"] = numberState{class: classFraction, value: val.value}
+	}
 }
 
 func NewLexer(inputFile *os.File) *Lexer {
-	file := inputFile
-
-	if file == nil {
-		file = os.Stdin
-	}
-
-	return &Lexer{
-		scannerStdIn: bufio.NewReader(file),
-		numberDict: map[string]numberState{
-			"um":              {state: 6, value: "1"},
-			"dois":            {state: 6, value: "2"},
-			"tres":            {state: 6, value: "3"},
-			"quatro":          {state: 6, value: "4"},
-			"cinco":           {state: 6, value: "5"},
-			"seis":            {state: 6, value: "6"},
-			"sete":            {state: 6, value: "7"},
-			"oito":            {state: 6, value: "8"},
-			"nove":            {state: 6, value: "9"},
-			"dez":             {state: 6, value: "10"},
-			"onze":            {state: 6, value: "11"},
-			"doze":            {state: 6, value: "12"},
-			"treze":           {state: 6, value: "13"},
-			"quatorze":        {state: 6, value: "14"},
-			"quinze":          {state: 6, value: "15"},
-			"dezesseis":       {state: 6, value: "16"},
-			"dezessete":       {state: 6, value: "17"},
-			"dezoito":         {state: 6, value: "18"},
-			"dezenove":        {state: 6, value: "19"},
-			"vinte":           {state: 7, value: "20"},
-			"trinta":          {state: 7, value: "30"},
-			"quarenta":        {state: 7, value: "40"},
-			"cinquenta":       {state: 7, value: "50"},
-			"sessenta":        {state: 7, value: "60"},
-			"setenta":         {state: 7, value: "70"},
-			"oitenta":         {state: 7, value: "80"},
-			"noventa":         {state: 7, value: "90"},
-			"cem":             {state: 8, value: "100"},
-			"cento":           {state: 9, value: "100"},
-			"duzentos":        {state: 10, value: "200"},
-			"trezentos":       {state: 10, value: "300"},
-			"quatrocentos":    {state: 10, value: "400"},
-			"quinhentos":      {state: 10, value: "500"},
-			"seiscentos":      {state: 10, value: "600"},
-			"setecentos":      {state: 10, value: "700"},
-			"oitocentos":      {state: 10, value: "800"},
-			"novecentos":      {state: 10, value: "900"},
-			"mil":             {state: 13, value: "1000"},
-			"milhao":          {state: 13, value: "1000000"},
-			"milhoes":         {state: 13, value: "1000000"},
-			"bilhao":          {state: 13, value: "1000000000"},
-			"bilhoes":         {state: 13, value: "1000000000"},
-			"trilhao":         {state: 13, value: "1000000000000"},
-			"trilhoes":        {state: 13, value: "1000000000000"},
-			"quadrilhao":      {state: 13, value: "1000000000000000"},
-			"quadrilhoes":     {state: 13, value: "1000000000000000"},
-			"quintilhao":      {state: 13, value: "1000000000000000000"},
-			"quintilhoes":     {state: 13, value: "1000000000000000000"},
-			"sextilhao":       {state: 13, value: "1000000000000000000000"},
-			"sextilhoes":      {state: 13, value: "1000000000000000000000"},
-			"septilhao":       {state: 13, value: "1000000000000000000000000"},
-			"septilhoes":      {state: 13, value: "1000000000000000000000000"},
-			"setilhao":        {state: 13, value: "1000000000000000000000000"},
-			"setilhoes":       {state: 13, value: "1000000000000000000000000"},
-			"octilhao":        {state: 13, value: "1000000000000000000000000000"},
-			"octilhoes":       {state: 13, value: "1000000000000000000000000000"},
-			"nonilhao":        {state: 13, value: "1000000000000000000000000000000"},
-			"nonilhoes":       {state: 13, value: "1000000000000000000000000000000"},
-			"decilhao":        {state: 13, value: "1000000000000000000000000000000000"},
-			"decilhoes":       {state: 13, value: "1000000000000000000000000000000000"},
-			"undecilhao":      {state: 13, value: "1000000000000000000000000000000000000"},
-			"undecilhoes":     {state: 13, value: "1000000000000000000000000000000000000"},
-			"duodecilhao":     {state: 13, value: "1000000000000000000000000000000000000000"},
-			"duodecilhoes":    {state: 13, value: "1000000000000000000000000000000000000000"},
-			"tridecilhao":     {state: 13, value: "1000000000000000000000000000000000000000000"},
-			"tridecilhoes":    {state: 13, value: "1000000000000000000000000000000000000000000"},
-			"quatradecilhao":  {state: 13, value: "1000000000000000000000000000000000000000000000"},
-			"quatradecilhoes": {state: 13, value: "1000000000000000000000000000000000000000000000"},
-			"zero":            {state: 15, value: "0"},
-			"e":               {state: 200, value: "0"},
-		},
+	if inputFile == nil {
+		return NewLexerFromReader(os.Stdin)
+	}
+
+	return NewLexerFromReader(inputFile)
+}
+
+// NewLexerFromReader creates a Lexer that reads its lines from reader.
+// A nil reader falls back to os.Stdin, like NewLexer.
+func NewLexerFromReader(reader io.Reader) *Lexer {
+	if reader == nil {
+		reader = os.Stdin
+	}
+
+	lexer := &Lexer{reader: bufio.NewReader(reader)}
+
+	lexer.SetLocale(PtBR)
+	lexer.SetCurrency(Currencies["BRL"])
+
+	return lexer
+}
+
+// SetLocale selects the variant of Portuguese read, which sets the value of
+// the scale words: "bilhao" or "biliao" is 10^9 in PtBR and 10^12 in PtPT.
+// Both variants read "mil milhoes" as 10^9 and the spellings of both, such as
+// "dezesseis" and "dezasseis".
+func (l *Lexer) SetLocale(locale Locale) {
+	l.locale = locale
+	l.numberDict = locale.vocabulary()
+}
+
+// SetCurrency selects the currency whose units are read in money amounts, as
+// in "dez reais e cinquenta centavos", BRL by default. The tokens of those
+// amounts carry its code.
+func (l *Lexer) SetCurrency(currency Currency) {
+	if l.localDict == nil {
+		l.localDict = make(map[string]numberState, 4)
+	}
+
+	for word, val := range l.localDict {
+		if val.class == classMajor || val.class == classMinor {
+			delete(l.localDict, word)
+		}
+	}
+
+	l.currency = currency
+
+	for _, word := range []string{currency.Major.Singular, currency.Major.Plural} {
+		l.localDict[removeAccents(word)] = numberState{class: classMajor, value: "0", gender: currency.Major.Gender}
+	}
+
+	for _, word := range []string{currency.Minor.Singular, currency.Minor.Plural} {
+		if word != "" {
+			l.localDict[removeAccents(word)] = numberState{class: classMinor, value: "0", gender: currency.Minor.Gender}
+		}
 	}
 }
 
@@ -143,137 +326,225 @@ func (l *Lexer) SetVerbose(verbose bool) {
 	l.verbose = verbose
 }
 
+// SetRecovery makes the lexer go on after an error instead of stopping at the
+// first one. The rejected word is reported and lexing resumes at the next
+// operator or number word, so every error of the line is returned along with
+// the tokens recognised around them.
+func (l *Lexer) SetRecovery(recovery bool) {
+	l.recovery = recovery
+}
+
+// SetSymbols makes the lexer accept the operator symbols + - * x / ^ % ! ( )
+// besides the operator words. Symbols do not need spaces around them, as in
+// "(dez+cinco)*2", and produce the same tokens as the words.
+func (l *Lexer) SetSymbols(symbols bool) {
+	l.symbols = symbols
+}
+
+// NextLine reads and lexes the next line of the input. A "q" or an empty
+// line yields no tokens, which interactive callers take as end of session.
+//
+// Deprecated: use Lines or Tokens.
 func (l *Lexer) NextLine() ([]Token, error) {
-	line, err := l.scannerStdIn.ReadString('\n')
+	line, err := l.readLine()
 
 	if err != nil {
 		return []Token{}, err
 	}
 
-	line = strings.TrimSuffix(line, "\n")
-
 	if line == "q" || line == "" {
 		return []Token{}, nil
 	}
 
-	return l.ParseLine(line)
+	return l.parseLine(line, l.line)
 }
 
-func (l *Lexer) ParseLine(rawLine string) ([]Token, error) {
-	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
-	line, _, err := transform.String(t, rawLine)
+// Lines returns an iterator over the tokens of each line read from the input.
+// Blank lines yield an empty slice. Iteration stops at the end of the input
+// or after a read error is yielded.
+func (l *Lexer) Lines() iter.Seq2[[]Token, error] {
+	return func(yield func([]Token, error) bool) {
+		for {
+			line, err := l.readLine()
 
-	if err != nil {
-		return []Token{}, err
+			if err == io.EOF {
+				return
+			}
+
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(l.parseLine(line, l.line)) {
+				return
+			}
+		}
 	}
+}
 
-	if !l.verbose {
-		log.SetOutput(io.Discard)
+// readLine reads the next line of the input without its line terminator. It
+// returns io.EOF only once there is nothing left to read.
+func (l *Lexer) readLine() (string, error) {
+	line, err := l.reader.ReadString('\n')
 
-		defer log.SetOutput(os.Stdout)
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
 	}
 
-	line = strings.Join(strings.Fields(strings.ToLower(line)), " ")
+	l.line++
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Tokens returns an iterator over every token read from the input, across
+// lines. The end of each line is signalled by a TOKEN_EOL token, which the
+// Parser reads as the end of input: split the stream on it to parse one
+// expression per line.
+func (l *Lexer) Tokens() iter.Seq2[Token, error] {
+	return func(yield func(Token, error) bool) {
+		for tokens, err := range l.Lines() {
+			if err != nil {
+				yield(Token{Type: TOKEN_ERROR}, err)
+				return
+			}
+
+			for _, token := range tokens {
+				if !yield(token, nil) {
+					return
+				}
+			}
+
+			if !yield(Token{Type: TOKEN_EOL}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ParseLine splits rawLine into tokens. Token positions are reported as if
+// rawLine were the first line of the input.
+func (l *Lexer) ParseLine(rawLine string) ([]Token, error) {
+	return l.parseLine(rawLine, 1)
+}
+
+func (l *Lexer) parseLine(rawLine string, lineNumber int) ([]Token, error) {
+	// Scales may have been edited since the last line
+	l.numberDict = l.locale.vocabulary()
+
+	words := splitWords(rawLine, lineNumber, l.symbols)
+	l.classifyWords(words)
+
+	ahead := lookahead{words: words}
 
 	tokens := make([]Token, 0, 64)
 
-	words := strings.Split(line, " ")
+	// Lexemes read past the last word are located at the end of the line
+	eol := word{pos: endOfLine(rawLine, lineNumber, len(words))}
+	eol.end = eol.pos
+	eol.class, eol.val = l.classify("")
 
 	index := 0
 
-	state := 0
+	state := stateStart
+
+	// The words of the operator phrase being read in statePhrase
+	operatorStart := eol
+	phrase := []string{}
 
 	numberTokens := make([]Token, 0)
 	for {
-		lexeme := ""
+		current := eol
 
 		if index < len(words) {
-			lexeme = words[index]
+			current = words[index]
 		}
 
-		if lexeme == "" && state == 0 {
+		lexeme := current.lexeme
+
+		if lexeme == "" && state == stateStart {
 			break
 		}
 
 		if l.verbose {
-			log.Printf("state: %d | lexeme: %s\n", state, lexeme)
+			log.Printf("state: %s | lexeme: %s\n", lexTable[state].name, lexeme)
 		}
 
-		if state == 0 {
-			if len(numberTokens) > 0 {
-				tokens = append(tokens, l.getNumberTokenFromList(numberTokens))
+		start := current
 
-				numberTokens = make([]Token, 0, len(numberTokens)+1)
-			}
+		if state == statePhrase {
+			start = operatorStart
+		}
 
-			state, numberTokens, tokens = l.q0(lexeme, numberTokens, tokens)
-		} else if state == 1 {
-			state, numberTokens, tokens = l.q1(lexeme, numberTokens, tokens)
-		} else if state == 2 {
-			state, numberTokens, tokens = l.q2(lexeme, numberTokens, tokens)
-		} else if state == 3 {
-			state, numberTokens, tokens = l.q3(lexeme, numberTokens, tokens)
-		} else if state == 4 {
-			state, numberTokens, tokens = l.q4(lexeme, numberTokens, tokens)
-		} else if state == 5 {
-			state, numberTokens, tokens = l.q5(lexeme, numberTokens, tokens)
-		} else if state == 6 {
-			state, numberTokens, tokens = l.q6(lexeme, numberTokens, tokens)
-
-			if state == 0 {
-				index--
-			}
-		} else if state == 7 {
-			state, numberTokens, tokens = l.q7(lexeme, numberTokens, tokens)
+		if state == stateStart && len(numberTokens) > 0 {
+			tokens = append(tokens, l.getNumberTokenFromList(numberTokens))
 
-			if state == 0 {
-				index--
-			}
-		} else if state == 8 {
-			state, numberTokens, tokens = l.q8(lexeme, numberTokens, tokens)
+			numberTokens = make([]Token, 0, len(numberTokens)+1)
+		}
 
-			if state == 0 {
-				index--
-			}
-		} else if state == 9 {
-			state, numberTokens, tokens = l.q9(lexeme, numberTokens, tokens)
+		wordIndex, tokenCount, from := index, len(tokens), state
 
-			if state == 0 {
-				index--
-			}
-		} else if state == 10 {
-			state, numberTokens, tokens = l.q10(lexeme, numberTokens, tokens)
+		class, val := current.class, current.val
+		transition := state.transition(class)
 
-			if state == 0 {
-				index--
+		if transition.lookahead != nil && !ahead.closedBy(index+1, transition.lookahead) {
+			transition = lexTable[state].otherNumber
+		}
+
+		switch transition.action {
+		case actionPush:
+			if transition.split {
+				numberTokens = append(numberTokens, Token{Type: TOKEN_DIVIDE, Value: symbolValues[TOKEN_DIVIDE]})
 			}
-		} else if state == 11 {
-			state, numberTokens, tokens = l.q11(lexeme, numberTokens, tokens)
-		} else if state == 12 {
-			state, numberTokens, tokens = l.q12(lexeme, numberTokens, tokens)
-		} else if state == 13 {
-			state, numberTokens, tokens = l.q13(lexeme, numberTokens, tokens)
-
-			if state == 0 {
-				index--
+
+			numberTokens = append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme})
+			state = transition.next
+		case actionSkip:
+			state = transition.next
+		case actionFinish:
+			// The word is read again from q0, once the number is closed
+			state = stateStart
+			index--
+		case actionOperator:
+			if state == stateStart {
+				operatorStart, phrase = current, phrase[:0]
 			}
-		} else if state == 14 {
-			state, numberTokens, tokens = l.q14(lexeme, numberTokens, tokens)
 
-			if state == 0 {
-				index--
+			state, tokens = l.readOperator(phrase, lexeme, tokens)
+			phrase = append(phrase, lexeme)
+		case actionError:
+			tokens = append(tokens, newErrorToken(transition.code, lexeme, transition.errorMessage(lexeme), transition.expected...))
+			state = stateStart
+		}
+
+		locate(tokens, start, current)
+		locate(numberTokens, start, current)
+
+		if l.recovery && hasError(tokens[tokenCount:]) {
+			errorTokens := slices.Clone(tokens[tokenCount:])
+			tokens = tokens[:tokenCount]
+
+			// The number read so far comes before the word it rejected
+			if len(numberTokens) > 0 {
+				tokens = append(tokens, l.getNumberTokenFromList(numberTokens))
+
+				numberTokens = make([]Token, 0, len(numberTokens)+1)
 			}
-		} else if state == 15 {
-			state, numberTokens, tokens = l.q15(lexeme, numberTokens, tokens)
 
-			if state == 0 {
-				index--
+			tokens = append(tokens, errorTokens...)
+
+			// The rejected word is read again from q0 when it may start a
+			// token there, as "mais" in "cento mais dois"
+			if from == stateStart {
+				wordIndex++
 			}
-		} else {
-			tokens = append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: fmt.Sprintf("Lexema '%s' não reconhecido", lexeme)})
+
+			index, state = resync(words, wordIndex), stateStart
+
+			continue
 		}
 
-		if len(tokens) > 0 && tokens[len(tokens)-1].Type == TOKEN_ERROR {
+		if !l.recovery && len(tokens) > 0 && tokens[len(tokens)-1].Type == TOKEN_ERROR {
 			break
 		}
 
@@ -289,283 +560,855 @@ func (l *Lexer) ParseLine(rawLine string) ([]Token, error) {
 	return tokens, nil
 }
 
-func (l Lexer) q0(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if lexeme == "mais" {
-		return 0, numberTokens, append(tokens, Token{Type: TOKEN_PLUS, Value: "+"})
-	}
+// operatorPhrase is a sequence of words standing for an operator. Phrases
+// such as "ao quadrado" also carry the operand they imply.
+type operatorPhrase struct {
+	words    []string
+	operator TokenType
+	exponent int64
+}
 
-	if lexeme == "menos" {
-		return 0, numberTokens, append(tokens, Token{Type: TOKEN_MINUS, Value: "-"})
-	}
+// operatorPhrases is the operator vocabulary. No phrase may be the prefix of
+// another one, so a phrase is complete as soon as all of its words are read.
+var operatorPhrases = []operatorPhrase{
+	{words: []string{"mais"}, operator: TOKEN_PLUS},
+	{words: []string{"menos"}, operator: TOKEN_MINUS},
+	{words: []string{"vezes"}, operator: TOKEN_TIMES},
+	{words: []string{"multiplicado", "por"}, operator: TOKEN_TIMES},
+	{words: []string{"dividido", "por"}, operator: TOKEN_DIVIDE},
+	{words: []string{"sobre"}, operator: TOKEN_DIVIDE},
+	{words: []string{"mod"}, operator: TOKEN_MOD},
+	{words: []string{"elevado", "por"}, operator: TOKEN_POWER},
+	{words: []string{"elevado", "a"}, operator: TOKEN_POWER},
+	{words: []string{"elevado", "ao", "quadrado"}, operator: TOKEN_POWER, exponent: 2},
+	{words: []string{"elevado", "ao", "cubo"}, operator: TOKEN_POWER, exponent: 3},
+	{words: []string{"ao", "quadrado"}, operator: TOKEN_POWER, exponent: 2},
+	{words: []string{"ao", "cubo"}, operator: TOKEN_POWER, exponent: 3},
+	{words: []string{"fatorial", "de"}, operator: TOKEN_FACTORIAL},
+	{words: []string{"abre", "parentese"}, operator: TOKEN_LEFT_BRACKET},
+	{words: []string{"abre", "parenteses"}, operator: TOKEN_LEFT_BRACKET},
+	{words: []string{"fecha", "parentese"}, operator: TOKEN_RIGHT_BRACKET},
+	{words: []string{"fecha", "parenteses"}, operator: TOKEN_RIGHT_BRACKET},
+}
 
-	if lexeme == "vezes" {
-		return 0, numberTokens, append(tokens, Token{Type: TOKEN_TIMES, Value: "*"})
-	}
+// matchPhrases returns the phrase made of exactly words, if any, and the
+// words that may follow words in a longer phrase.
+func matchPhrases(words []string) (*operatorPhrase, []string) {
+	var next []string
 
-	if lexeme == "mod" {
-		return 0, numberTokens, append(tokens, Token{Type: TOKEN_MOD, Value: "%"})
-	}
+	for i := range operatorPhrases {
+		phrase := &operatorPhrases[i]
+
+		if len(phrase.words) < len(words) || !slices.Equal(phrase.words[:len(words)], words) {
+			continue
+		}
+
+		if len(phrase.words) == len(words) {
+			return phrase, nil
+		}
 
-	if lexeme == "elevado" {
-		return 1, numberTokens, tokens
+		if word := phrase.words[len(words)]; !slices.Contains(next, word) {
+			next = append(next, word)
+		}
 	}
 
-	if lexeme == "abre" {
-		return 2, numberTokens, tokens
+	return nil, next
+}
+
+// phraseStarts holds the first word of each operator phrase.
+var phraseStarts = func() map[string]bool {
+	starts := make(map[string]bool, len(operatorPhrases))
+
+	for _, phrase := range operatorPhrases {
+		starts[phrase.words[0]] = true
 	}
 
-	if lexeme == "fecha" {
-		return 3, numberTokens, tokens
+	return starts
+}()
+
+// emit returns the tokens of the phrase: its operator, followed by the
+// implied exponent if any.
+func (o operatorPhrase) emit() []Token {
+	tokens := []Token{{Type: o.operator, Value: symbolValues[o.operator]}}
+
+	if o.exponent != 0 {
+		exponent := big.NewInt(o.exponent)
+
+		tokens = append(tokens, Token{Type: TOKEN_NUMBER_PARSED, Value: exponent.String(), Spell: o.words[len(o.words)-1], Number: exponent, Rat: new(big.Rat).SetInt(exponent)})
 	}
 
-	if lexeme == "fatorial" {
-		return 4, numberTokens, tokens
+	return tokens
+}
+
+// symbolValues is the Value of the tokens created for each operator
+var symbolValues = map[TokenType]string{
+	TOKEN_PLUS:          "+",
+	TOKEN_MINUS:         "-",
+	TOKEN_TIMES:         "*",
+	TOKEN_DIVIDE:        "/",
+	TOKEN_POWER:         "^",
+	TOKEN_MOD:           "%",
+	TOKEN_FACTORIAL:     "!",
+	TOKEN_LEFT_BRACKET:  "(",
+	TOKEN_RIGHT_BRACKET: ")",
+}
+
+func (l Lexer) symbolOperator(lexeme string) (TokenType, bool) {
+	if !l.symbols {
+		return TOKEN_ERROR, false
 	}
 
-	if lexeme == "dividido" {
-		return 5, numberTokens, tokens
+	if lexeme == "x" {
+		return TOKEN_TIMES, true
 	}
 
-	if val, ok := l.numberDict[lexeme]; ok {
-		if _, ok := l.isOneState(lexeme, []int{6, 7, 8, 9, 10, 15}); ok || val.value == "1000" {
-			return val.state, append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme}), tokens
+	tokenType, ok := symbolOperators[lexeme]
+
+	return tokenType, ok
+}
+
+func hasError(tokens []Token) bool {
+	for _, token := range tokens {
+		if token.Type == TOKEN_ERROR {
+			return true
 		}
 	}
 
-	return 0, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: fmt.Sprintf("Lexema '%s' não reconhecido", lexeme)})
+	return false
 }
 
-func (l Lexer) q1(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if lexeme != "por" {
-		return 0, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Expected 'por' after 'elevado'"})
+// resync returns the index of the first word from index on that can start a
+// token: an operator word or a number word.
+func resync(words []word, index int) int {
+	for ; index < len(words); index++ {
+		if stateStart.transition(words[index].class).action != actionError {
+			return index
+		}
 	}
 
-	return 0, numberTokens, append(tokens, Token{Type: TOKEN_POWER, Value: "^"})
+	return index
 }
 
-func (l Lexer) checkParenthesis(lexeme string) bool {
-	regex := regexp.MustCompile(`^parenteses?$`)
-	return regex.MatchString(lexeme)
+// lookahead answers, for the words of a line, whether the number words from
+// an index on are followed by a word of one of the classes of a transition
+// lookahead. The answers for each lookahead are worked out at once, from the
+// last word back, the first time it is asked for, so that no word is read
+// more than once per lookahead.
+type lookahead struct {
+	words  []word
+	closed map[uint64][]bool
 }
 
-func (l Lexer) q2(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if l.checkParenthesis(lexeme) {
-		return 0, numberTokens, append(tokens, Token{Type: TOKEN_LEFT_BRACKET, Value: "("})
+// closedBy reports whether the number words from index on are followed by a
+// word of one of the classes.
+func (a *lookahead) closedBy(index int, classes []wordClass) bool {
+	var set uint64
+
+	for _, class := range classes {
+		set |= 1 << class
 	}
 
-	return 0, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Esperado 'parentese(s)' após 'abre'"})
+	closed, ok := a.closed[set]
+
+	if !ok {
+		closed = make([]bool, len(a.words)+1)
+
+		for i := len(a.words) - 1; i >= 0; i-- {
+			class := a.words[i].class
+
+			closed[i] = set&(1<<class) != 0 || class.isNumber() && closed[i+1]
+		}
+
+		if a.closed == nil {
+			a.closed = make(map[uint64][]bool)
+		}
+
+		a.closed[set] = closed
+	}
+
+	return closed[index]
+}
+
+// classifyWords looks up the class and value of each word.
+func (l Lexer) classifyWords(words []word) {
+	for i := range words {
+		words[i].class, words[i].val = l.classify(words[i].lexeme)
+	}
 }
 
-func (l Lexer) q3(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if l.checkParenthesis(lexeme) {
-		return 0, numberTokens, append(tokens, Token{Type: TOKEN_RIGHT_BRACKET, Value: ")"})
+// classify returns the class of lexeme and, for number words and digit
+// literals, their value.
+func (l Lexer) classify(lexeme string) (wordClass, numberState) {
+	if _, ok := l.symbolOperator(lexeme); ok {
+		return classOperator, numberState{}
+	}
+
+	if phraseStarts[lexeme] {
+		return classOperator, numberState{}
+	}
+
+	if val, ok := l.lookup(lexeme); ok {
+		return val.class, val
 	}
 
-	return 0, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Esperado 'parentese(s)' após 'fecha'"})
+	return classOther, numberState{}
 }
 
-func (l Lexer) q4(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if lexeme != "de" {
-		return 0, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Esperado 'de' após 'fatorial'"})
+// readOperator reads lexeme as the next word of phrase, the operator phrase
+// or symbol being read.
+func (l Lexer) readOperator(phrase []string, lexeme string, tokens []Token) (lexState, []Token) {
+	if tokenType, ok := l.symbolOperator(lexeme); ok && len(phrase) == 0 {
+		return stateStart, append(tokens, Token{Type: tokenType, Value: symbolValues[tokenType]})
+	}
+
+	words := append(slices.Clone(phrase), lexeme)
+
+	if complete, next := matchPhrases(words); complete != nil {
+		return stateStart, append(tokens, complete.emit()...)
+	} else if len(next) > 0 {
+		return statePhrase, tokens
+	}
+
+	_, expected := matchPhrases(phrase)
 
+	quoted := make([]string, 0, len(expected))
+
+	for _, word := range expected {
+		quoted = append(quoted, "'"+word+"'")
+	}
+
+	alternatives := quoted[len(quoted)-1]
+
+	if len(quoted) > 1 {
+		alternatives = strings.Join(quoted[:len(quoted)-1], ", ") + " ou " + alternatives
 	}
 
-	return 0, numberTokens, append(tokens, Token{Type: TOKEN_FACTORIAL, Value: "!"})
+	message := fmt.Sprintf("Esperado %s após '%s'", alternatives, strings.Join(phrase, " "))
+
+	return stateStart, append(tokens, newErrorToken(ErrExpectedWord, lexeme, message, expected...))
 }
 
-func (l Lexer) q5(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if lexeme != "por" {
-		return 0, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Esperado 'por' após 'dividido'"})
+// errorMessage returns the message of an actionError transition on lexeme.
+func (t lexTransition) errorMessage(lexeme string) string {
+	if strings.Contains(t.message, "%s") {
+		return fmt.Sprintf(t.message, lexeme)
 	}
 
-	return 0, numberTokens, append(tokens, Token{Type: TOKEN_DIVIDE, Value: "/"})
+	return t.message
 }
 
-func (l Lexer) q6(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if val, ok := l.numberDict[lexeme]; ok {
-		if val.state != 13 {
-			return 6, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Não é esperado um número após '{unidade}'"})
+// lookup returns the class and value of a number word or of a digit literal.
+// Digit literals are complete numbers: like a {unidade}, only a scale word
+// can follow them. Abbreviated ordinals are read like a {quarto}.
+func (l Lexer) lookup(lexeme string) (numberState, bool) {
+	if val, ok := l.wordState(lexeme); ok {
+		return val, true
+	}
+
+	for _, indicator := range []string{"º", "ª"} {
+		if digits, ok := strings.CutSuffix(lexeme, indicator); ok {
+			if value, ok := parseDigits(digits); ok && value.IsInt() && value.Sign() > 0 {
+				return numberState{class: classOrdinalUnit, value: value.RatString()}, true
+			}
 		}
+	}
+
+	value, ok := parseDigits(lexeme)
 
-		return val.state, append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme}), tokens
+	if !ok {
+		return numberState{}, false
 	}
 
-	return 0, numberTokens, tokens
+	if value.Sign() == 0 {
+		return numberState{class: classZero, value: "0"}, true
+	}
+
+	return numberState{class: classUnit, value: value.RatString()}, true
 }
 
-func (l Lexer) q7(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if lexeme == "e" {
-		return 12, numberTokens, tokens
+// wordState returns the state of a number word: its entry in the dictionary
+// or, for the scale names past Scales, the one read by latinScaleWord.
+func (l Lexer) wordState(lexeme string) (numberState, bool) {
+	if val, ok := l.localDict[lexeme]; ok {
+		return val, true
 	}
 
 	if val, ok := l.numberDict[lexeme]; ok {
-		if val.state != 13 {
-			return 7, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Não é esperado um número após '{dezena}'"})
-		}
+		return val, true
+	}
+
+	return l.latinScaleWord(lexeme)
+}
 
-		return val.state, append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme}), tokens
+// latinScaleWord reads the scale names missing from Scales, built from the
+// Latin prefixes of their ranks, as in "vigintilhoes", along with their
+// ordinals in both genders and, as fraction words, their plurals, as
+// addOrdinal does.
+func (l Lexer) latinScaleWord(lexeme string) (numberState, bool) {
+	word, gender, class := lexeme, Masculine, classOrdinalScale
+
+	switch {
+	case strings.HasSuffix(lexeme, "esimos"):
+		word, class = strings.TrimSuffix(lexeme, "s"), classFraction
+	case strings.HasSuffix(lexeme, "esima"):
+		word, gender = strings.TrimSuffix(lexeme, "a")+"o", Feminine
 	}
 
-	return 0, numberTokens, tokens
+	rank, ordinal, ok := latinScale(word)
+
+	if !ok {
+		return numberState{}, false
+	}
+
+	value := l.locale.scaleValue(rank).String()
+
+	if !ordinal {
+		return numberState{class: classScale, value: value}, true
+	}
+
+	return numberState{class: class, value: value, gender: gender}, true
 }
 
-func (l Lexer) isOneState(lexeme string, states []int) (numberState, bool) {
-	for _, state := range states {
+// parseDigits reads a pt-BR digit literal: digits optionally grouped by '.'
+// thousands separators ("1.250.000") and a decimal part after ',' ("1,5").
+func parseDigits(lexeme string) (*big.Rat, bool) {
+	if lexeme == "" || lexeme[0] < '0' || lexeme[0] > '9' {
+		return nil, false
+	}
+
+	integer, decimal, hasDecimal := strings.Cut(lexeme, ",")
+
+	if hasDecimal && !isDigits(decimal) {
+		return nil, false
+	}
+
+	groups := strings.Split(integer, ".")
 
-		if l.numberDict[lexeme].state == state {
-			return l.numberDict[lexeme], true
+	for i, group := range groups {
+		if !isDigits(group) || len(groups) > 1 && (len(group) > 3 || i > 0 && len(group) != 3) {
+			return nil, false
 		}
 	}
 
-	return numberState{}, false
+	value, ok := new(big.Rat).SetString(strings.Join(groups, "") + "." + decimal)
+
+	return value, ok
 }
 
-func (l Lexer) q8(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if val, ok := l.numberDict[lexeme]; ok {
-		if _, ok := l.isOneState(lexeme, []int{13}); ok {
-			return val.state, append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme}), tokens
-		}
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
 
-		return 8, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Não é esperado U/D/C após 'cem'"})
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
 	}
 
-	return 0, numberTokens, tokens
+	return true
 }
 
-func (l Lexer) q9(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if lexeme == "e" {
-		return 11, numberTokens, tokens
+// lexError returns the failure behind an error token, building one for
+// tokens created by hand without it.
+func (t Token) lexError() *LexError {
+	if t.Err != nil {
+		return t.Err
 	}
 
-	return 0, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Esperado 'e' após 'cento'"})
+	return &LexError{Code: ErrUnknownLexeme, Pos: t.Pos, End: t.End, Lexeme: t.Value, Message: t.Spell}
 }
 
-func (l Lexer) q10(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if lexeme == "e" {
-		return 11, numberTokens, tokens
+// removeAccents strips the accents of s, as in "milhão" to "milhao".
+func removeAccents(s string) string {
+	return fold(s, false)
+}
+
+// fold strips the accents of s, the nonspacing marks of its canonical
+// decomposition, and puts it in lower case when lower is set, in a single
+// pass over s. Text that has nothing to fold, as most words read, is
+// returned as it is, without copying.
+func fold(s string, lower bool) string {
+	i := 0
+
+	for i < len(s) && s[i] < utf8.RuneSelf && !(lower && 'A' <= s[i] && s[i] <= 'Z') {
+		i++
 	}
 
-	if val, ok := l.numberDict[lexeme]; ok {
-		if _, ok := l.isOneState(lexeme, []int{13}); ok {
-			return val.state, append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme}), tokens
+	if i == len(s) {
+		return s
+	}
+
+	folded := make([]byte, i, len(s))
+	copy(folded, s[:i])
+
+	for offset, r := range s[i:] {
+		if r < utf8.RuneSelf {
+			folded = appendFolded(folded, r, lower)
+			continue
+		}
+
+		decomposition := norm.NFD.PropertiesString(s[i+offset:]).Decomposition()
+
+		if decomposition == nil {
+			folded = appendFolded(folded, r, lower)
+			continue
 		}
 
-		return 8, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Esperado 'e' ou milhar após '{centena}'"})
+		for _, r := range string(decomposition) {
+			folded = appendFolded(folded, r, lower)
+		}
 	}
 
-	return 0, numberTokens, tokens
+	return string(folded)
 }
 
-func (l Lexer) q11(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if val, ok := l.isOneState(lexeme, []int{6, 7}); ok {
-		return val.state, append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme}), tokens
+// appendFolded appends r to folded, in lower case when lower is set, unless
+// it is an accent.
+func appendFolded(folded []byte, r rune, lower bool) []byte {
+	if unicode.Is(unicode.Mn, r) {
+		return folded
+	}
+
+	if lower {
+		r = unicode.ToLower(r)
 	}
 
-	return 10, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Esperado dezena ou unidade após '{centena} e'"})
+	return utf8.AppendRune(folded, r)
 }
 
-func (l Lexer) q12(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if val, ok := l.isOneState(lexeme, []int{6}); ok {
-		return val.state, append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme}), tokens
+// splitWords breaks rawLine into normalised (lower case, without accents)
+// words, keeping the position of each one in rawLine. When symbols is set,
+// each operator symbol is a word of its own, even if glued to other words.
+func splitWords(rawLine string, lineNumber int, symbols bool) []word {
+	words := make([]word, 0, 16)
+
+	start := -1
+	startColumn := 0
+	column := 1
+
+	flush := func(offset int) {
+		if start < 0 {
+			return
+		}
+
+		words = append(words, word{
+			lexeme: fold(rawLine[start:offset], true),
+			pos:    Position{Offset: start, Line: lineNumber, Column: startColumn, Word: len(words)},
+			end:    Position{Offset: offset, Line: lineNumber, Column: column, Word: len(words)},
+		})
+
+		start = -1
+	}
+
+	for offset, r := range rawLine {
+		if unicode.IsSpace(r) {
+			flush(offset)
+		} else if _, ok := symbolOperators[string(r)]; symbols && ok {
+			flush(offset)
+
+			start, startColumn = offset, column
+			column++
+
+			flush(offset + 1)
+
+			continue
+		} else if start < 0 {
+			start, startColumn = offset, column
+		}
+
+		column++
 	}
 
-	return 10, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Esperado unidade após '{dezena} e'"})
+	flush(len(rawLine))
+
+	return words
 }
 
-func (l Lexer) q13(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if lexeme == "e" {
-		return 14, numberTokens, tokens
+func endOfLine(rawLine string, lineNumber int, wordCount int) Position {
+	return Position{
+		Offset: len(rawLine),
+		Line:   lineNumber,
+		Column: utf8.RuneCountInString(rawLine) + 1,
+		Word:   wordCount,
 	}
+}
 
-	if val, ok := l.numberDict[lexeme]; ok {
-		if _, ok := l.isOneState(lexeme, []int{6, 7, 8, 9, 10}); ok || val.value == "1000" {
-			return val.state, append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme}), tokens
+// locate sets the span of the tokens that do not have one yet from start to
+// w, the word just read. Errors only point at w, the word that was rejected.
+func locate(tokens []Token, start word, w word) {
+	for i := len(tokens) - 1; i >= 0 && tokens[i].Pos.Line == 0; i-- {
+		if tokens[i].Type == TOKEN_ERROR {
+			tokens[i] = spanToken(tokens[i], w.pos, w.end)
+			continue
 		}
 
-		return 13, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Esperado 'e' ou U/C/D depois de '{milhar}'"})
+		tokens[i] = spanToken(tokens[i], start.pos, w.end)
 	}
+}
+
+func spanToken(token Token, pos Position, end Position) Token {
+	token.Pos, token.End = pos, end
 
-	return 0, numberTokens, tokens
+	if token.Err != nil {
+		token.Err.Pos, token.Err.End = pos, end
+	}
+
+	return token
 }
 
-func (l Lexer) q14(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if val, ok := l.numberDict[lexeme]; ok {
-		if _, ok := l.isOneState(lexeme, []int{6, 7, 8, 9, 10}); ok || val.value == "1000" {
-			return val.state, append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme}), tokens
+func newErrorToken(code ErrorCode, lexeme string, message string, expected ...string) Token {
+	return Token{
+		Type:  TOKEN_ERROR,
+		Value: lexeme,
+		Spell: message,
+		Err:   &LexError{Code: code, Lexeme: lexeme, Expected: expected, Message: message},
+	}
+}
+
+func (l Lexer) getNumberTokenFromList(numberTokens []Token) Token {
+	if len(numberTokens) == 0 {
+		return newErrorToken(ErrInvalidNumber, "", "Número vazio")
+	}
+
+	pos, end := numberTokens[0].Pos, numberTokens[len(numberTokens)-1].End
+
+	spells := make([]string, 0, len(numberTokens))
+
+	for _, token := range numberTokens {
+		// The separator of a fraction has no word of its own
+		if token.Type == TOKEN_NUMBER {
+			spells = append(spells, token.Spell)
 		}
-		return 14, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Esperado U/C/D depois de '{milhar} e'"})
 	}
 
-	return 0, numberTokens, tokens
+	spell := strings.Join(spells, " ")
+
+	if l.verbose {
+		log.Println(numberTokens)
+	}
+
+	invalid := func(message string) Token {
+		return spanToken(newErrorToken(ErrInvalidNumber, spell, fmt.Sprintf(message, spell)), pos, end)
+	}
+
+	total, message := l.numberValue(numberTokens)
+
+	if message != "" {
+		return invalid(message)
+	}
+
+	token := Token{Type: TOKEN_NUMBER_PARSED, Value: ratString(total), Spell: spell, Rat: total, Pos: pos, End: end}
+
+	if slices.ContainsFunc(numberTokens, l.isMoney) {
+		token.Currency = l.currency.Code
+	}
+
+	if total.IsInt() {
+		token.Number = new(big.Int).Set(total.Num())
+	}
+
+	return token
 }
 
-func (l Lexer) q15(lexeme string, numberTokens []Token, tokens []Token) (int, []Token, []Token) {
-	if _, ok := l.numberDict[lexeme]; ok {
-		return 15, numberTokens, append(tokens, Token{Type: TOKEN_ERROR, Value: lexeme, Spell: "Não esperado número após 'zero'"})
+// numberValue returns the value of the words of a number, or the format of the
+// message explaining why they do not make one. A fraction is split by a
+// TOKEN_DIVIDE token into its numerator and its denominator, which is a
+// fraction word or a number closed by "avos".
+func (l Lexer) numberValue(numberTokens []Token) (*big.Rat, string) {
+	if i := slices.IndexFunc(numberTokens, l.isClass(classMajor)); i >= 0 {
+		major := numberTokens[:i]
+
+		// "um milhao de reais"
+		if len(major) > 0 && l.isClass(classOf)(major[len(major)-1]) {
+			major = major[:len(major)-1]
+		}
+
+		return l.moneyValue(major, numberTokens[i+1:])
+	}
+
+	if l.isClass(classMinor)(numberTokens[len(numberTokens)-1]) {
+		return l.moneyValue(nil, numberTokens)
+	}
+
+	if i := slices.IndexFunc(numberTokens, func(token Token) bool { return token.Type == TOKEN_DIVIDE }); i >= 0 {
+		numerator, message := l.numberValue(numberTokens[:i])
+
+		if message != "" {
+			return nil, message
+		}
+
+		denominator := numberTokens[i+1:]
+
+		if l.isClass(classAvos)(denominator[len(denominator)-1]) {
+			denominator = denominator[:len(denominator)-1]
+		}
+
+		value, message := l.sumClasses(denominator)
+
+		if message != "" {
+			return nil, message
+		}
+
+		return numerator.Quo(numerator, value), ""
+	}
+
+	integer, decimal := numberTokens, []Token(nil)
+
+	if i := slices.IndexFunc(numberTokens, l.isClass(classComma)); i >= 0 {
+		integer, decimal = numberTokens[:i], numberTokens[i+1:]
+	}
+
+	total, message := l.sumClasses(integer)
+
+	if message != "" {
+		return nil, message
+	}
+
+	if decimal != nil {
+		fraction, scale, ok := l.decimalPart(decimal)
+
+		if !ok || scale != nil && slices.ContainsFunc(integer, l.isScale) {
+			return nil, "Parte decimal inválida em '%s'"
+		}
+
+		total.Add(total, fraction)
+
+		// "dois virgula cinco milhoes": the scale applies to the whole number
+		if scale != nil {
+			total.Mul(total, scale)
+		}
 	}
 
-	return 0, numberTokens, tokens
+	return total, ""
 }
 
-func (l Lexer) getNumberTokenFromList(numberTokens []Token) Token {
-	if len(numberTokens) == 0 {
-		return Token{Type: TOKEN_ERROR, Value: "0"}
+// moneyValue returns the value of a money amount: the words of the amount in
+// the major unit, then those of the amount in the minor unit, closed by its
+// name or not, as in "dez reais e cinquenta". Either may be missing, as in
+// "cinquenta centavos". After the major unit, the minor one must be an
+// integer below its scale.
+func (l Lexer) moneyValue(major []Token, minor []Token) (*big.Rat, string) {
+	total := new(big.Rat)
+
+	if len(major) > 0 {
+		value, message := l.numberValue(major)
+
+		if message != "" {
+			return nil, message
+		}
+
+		total.Add(total, value)
 	}
 
-	log.Println(numberTokens)
+	if len(minor) > 0 && l.isClass(classMinor)(minor[len(minor)-1]) {
+		minor = minor[:len(minor)-1]
+	}
 
-	order := 1
-	orderMilhar := len("1000")
+	if len(minor) == 0 {
+		return total, ""
+	}
 
-	number := big.NewInt(0)
+	if slices.ContainsFunc(minor, l.isMoney) {
+		return nil, "Valor monetário inválido em '%s'"
+	}
 
-	for i := len(numberTokens) - 1; i >= 0; i-- {
-		token := numberTokens[i]
+	value, message := l.numberValue(minor)
 
-		log.Println(token)
+	if message != "" {
+		return nil, message
+	}
 
-		tokenOrder := len(token.Value)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(l.currency.MinorDigits)), nil)
 
-		if tokenOrder >= orderMilhar {
-			if order > orderMilhar && tokenOrder <= order {
-				return Token{Type: TOKEN_ERROR, Value: "0"}
-			}
+	if !value.IsInt() || len(major) > 0 && value.Num().Cmp(scale) >= 0 {
+		return nil, "Valor monetário inválido em '%s'"
+	}
 
-			if len(number.String()) < order {
+	return total.Add(total, value.Quo(value, new(big.Rat).SetInt(scale))), ""
+}
 
-				number = big.NewInt(0).Add(number, big.NewInt(1).Exp(big.NewInt(10), big.NewInt(int64(order-1)), nil))
-			}
+// sumClasses returns the value of the words of a number, or the format of
+// the message explaining why they do not make one.
+func (l Lexer) sumClasses(numberTokens []Token) (*big.Rat, string) {
+	// total holds the classes already closed by a scale word above mil, group
+	// the class being read, which "mil" multiplies in place
+	total := new(big.Rat)
+	group := new(big.Rat)
+	thousand := false
 
-			order = tokenOrder
+	// previous is the scale word just read, which "e meio" halves
+	var lastScale, previous *big.Rat
+
+	// feminine is set when the class being read has a feminine word, which
+	// "milhao" and above, masculine nouns, do not take
+	feminine := false
+
+	// compound is set after "de", which makes the scale word that follows
+	// multiply the whole number, as in "um decilhao de decilhoes"
+	compound := false
+
+	for _, token := range numberTokens {
+		if l.verbose {
+			log.Println(token)
+		}
+
+		val, _ := l.wordState(token.Spell)
+
+		if val.class == classOf {
+			compound = true
 
 			continue
 		}
 
-		currentUnit := big.NewInt(0)
+		if val.gender == Feminine {
+			feminine = true
+		}
 
-		currentUnit, ok := currentUnit.SetString(token.Value, 10)
+		if val.class == classScale && feminine {
+			return nil, "Forma feminina antes de escala masculina em '%s'"
+		}
+
+		value, ok := new(big.Rat).SetString(token.Value)
 
 		if !ok {
-			return Token{Type: TOKEN_ERROR, Value: "0"}
+			return nil, "Número '%s' inválido"
+		}
+
+		if l.isClass(classHalf)(token) {
+			if previous != nil {
+				value.Mul(value, previous)
+			}
+
+			group.Add(group, value)
+
+			continue
 		}
 
-		exponent := big.NewInt(int64(order - 1))
+		if !l.isScale(token) {
+			group.Add(group, value)
+			previous = nil
 
-		orderNumber := big.NewInt(1).Exp(big.NewInt(10), exponent, nil)
+			continue
+		}
+
+		previous = value
+
+		if compound {
+			total.Add(total, group).Mul(total, value)
 
-		currentNumber := currentUnit.Mul(currentUnit, orderNumber)
+			group, thousand, lastScale, feminine, compound = new(big.Rat), false, value, false, false
 
-		number = number.Add(number, currentNumber)
+			continue
+		}
+
+		// "mil", "milhão"... not prefixed by {unidade} | {dezena} | {centena}
+		if group.Sign() == 0 {
+			group.SetInt64(1)
+		}
+
+		if value.Cmp(big.NewRat(1000, 1)) == 0 {
+			if thousand {
+				return nil, "Ordem das classes inválida em '%s'"
+			}
+
+			group.Mul(group, value)
+			thousand = true
+
+			continue
+		}
+
+		if lastScale != nil && value.Cmp(lastScale) >= 0 {
+			return nil, "Ordem das classes inválida em '%s'"
+		}
+
+		total.Add(total, group.Mul(group, value))
+
+		group, thousand, lastScale, feminine = new(big.Rat), false, value, false
+	}
+
+	return total.Add(total, group), ""
+}
+
+// decimalPart returns the value of the words after "virgula": the zeros
+// opening it, then an integer read as its digits. A scale word closing the
+// words multiplies the whole number and is returned apart.
+func (l Lexer) decimalPart(numberTokens []Token) (*big.Rat, *big.Rat, bool) {
+	zeros := 0
+
+	for zeros < len(numberTokens) && l.isClass(classZero)(numberTokens[zeros]) {
+		zeros++
+	}
+
+	digits := numberTokens[zeros:]
+
+	var scale *big.Rat
+
+	if len(digits) > 0 && l.isScale(digits[len(digits)-1]) {
+		scale, _ = new(big.Rat).SetString(digits[len(digits)-1].Value)
+		digits = digits[:len(digits)-1]
+	}
+
+	if slices.ContainsFunc(digits, l.isScale) || slices.ContainsFunc(digits, l.isClass(classHalf)) || slices.ContainsFunc(digits, l.isClass(classComma)) {
+		return nil, nil, false
+	}
+
+	if len(digits) == 0 {
+		return new(big.Rat), nil, scale == nil
+	}
+
+	value, message := l.sumClasses(digits)
+
+	if message != "" || !value.IsInt() {
+		return nil, nil, false
+	}
+
+	places := big.NewInt(int64(zeros + len(value.Num().String())))
+
+	return value.Quo(value, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), places, nil))), scale, true
+}
+
+// isScale reports whether token is "mil" or a scale word above it, cardinal
+// or ordinal.
+func (l Lexer) isScale(token Token) bool {
+	val, _ := l.wordState(token.Spell)
+	class := val.class
+
+	return class == classThousand || class == classScale || class == classOrdinalScale
+}
+
+func (l Lexer) isClass(class wordClass) func(Token) bool {
+	return func(token Token) bool {
+		val, ok := l.wordState(token.Spell)
+
+		return ok && val.class == class
 	}
+}
 
-	// Adjust in case of 'mil' not prefixed by {unidade} | {dezena} | {centena}
-	if order == 4 {
-		milhar := big.NewInt(1000)
+// isMoney reports whether token is the name of a unit of the currency.
+func (l Lexer) isMoney(token Token) bool {
+	return l.isClass(classMajor)(token) || l.isClass(classMinor)(token)
+}
 
-		if number.Cmp(milhar) == -1 {
-			number = big.NewInt(0).Add(number, milhar)
+// maxDecimalPlaces bounds the decimal expansions written for a number
+const maxDecimalPlaces = 20
+
+// ratString formats r as an integer or, when it has a finite decimal
+// expansion, as a decimal such as "2.5". Other fractions keep the form "1/3".
+func ratString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	places := 0
+
+	for scaled := new(big.Rat).Set(r); !scaled.IsInt(); places++ {
+		if places == maxDecimalPlaces {
+			return r.RatString()
 		}
+
+		scaled.Mul(scaled, big.NewRat(10, 1))
 	}
 
-	return Token{Type: TOKEN_NUMBER_PARSED, Value: number.String(), Number: number}
+	return r.FloatString(places)
 }
@@ -1,8 +1,20 @@
 package spellnumber
 
 import (
+	"bufio"
+	"errors"
+	"io"
+	"log"
+	"maps"
 	"math/big"
+	"slices"
+	"strings"
 	"testing"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 func TestLexerParseLine(t *testing.T) {
@@ -117,6 +129,263 @@ func TestLexerParseLine(t *testing.T) {
 				{Type: TOKEN_NUMBER_PARSED, Value: big.NewInt(1).Mul(big.NewInt(4), big.NewInt(1).Exp(big.NewInt(10), big.NewInt(33), nil)).String()},
 			},
 		},
+		{
+			name:  "Digit literal",
+			input: "1.250.000 mais dez",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "1250000"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "10"},
+			},
+		},
+		{
+			name:  "Digits and words",
+			input: "3 mil e 200",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "3200"},
+			},
+		},
+		{
+			name:  "Digits with scale",
+			input: "2 milhões vezes 7",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2000000"},
+				{Type: TOKEN_TIMES, Value: "*"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "7"},
+			},
+		},
+		{
+			name:  "Decimal digits with scale",
+			input: "1,5 milhão",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "1500000"},
+			},
+		},
+		{
+			name:  "Grouped digits with scale",
+			input: "1.250 milhoes e 300 mil",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "1250300000"},
+			},
+		},
+		{
+			name:  "Decimal digits without scale",
+			input: "1,5",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "1.5"},
+			},
+		},
+		{
+			name:  "Virgula",
+			input: "três vírgula catorze",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "3.14"},
+			},
+		},
+		{
+			name:  "Virgula com zeros",
+			input: "cento e dois vírgula zero zero vinte e cinco mais um",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "102.0025"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "1"},
+			},
+		},
+		{
+			name:  "Virgula com escala",
+			input: "dois vírgula cinco milhões",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2500000"},
+			},
+		},
+		{
+			name:  "Virgula sem parte decimal",
+			input: "dois vírgula",
+			expected: []Token{
+				{Type: TOKEN_ERROR},
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+			},
+		},
+		{
+			name:  "Duas virgulas",
+			input: "um vírgula dois vírgula três",
+			expected: []Token{
+				{Type: TOKEN_ERROR, Value: "um virgula dois virgula tres"},
+			},
+		},
+		{
+			name:  "E meio",
+			input: "dois e meio",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2.5"},
+			},
+		},
+		{
+			name:  "Milhão e meio",
+			input: "um milhão e meio",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "1500000"},
+			},
+		},
+		{
+			name:  "Mil e meio",
+			input: "dois mil e meio",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2500"},
+			},
+		},
+		{
+			name:  "Fração",
+			input: "três quartos mais um terço",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "0.75"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "1/3"},
+			},
+		},
+		{
+			name:  "Fração de milhar",
+			input: "dois mil centésimos",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "20"},
+			},
+		},
+		{
+			name:  "Avos",
+			input: "cinco onze avos",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "5/11"},
+			},
+		},
+		{
+			name:  "Avos composto",
+			input: "sete cento e vinte e um avos vezes dois",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "7/121"},
+				{Type: TOKEN_TIMES, Value: "*"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+			},
+		},
+		{
+			name:  "Número após unidade sem avos",
+			input: "dois onze",
+			expected: []Token{
+				{Type: TOKEN_ERROR, Value: "onze"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+			},
+		},
+		{
+			name:  "Feminino",
+			input: "trezentas e uma mais duas mil",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "301"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "2000"},
+			},
+		},
+		{
+			name:  "Feminino com milhões",
+			input: "dois milhões e duzentas mil",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2200000"},
+			},
+		},
+		{
+			name:  "Feminino antes de milhões",
+			input: "duzentas milhões",
+			expected: []Token{
+				{Type: TOKEN_ERROR, Value: "duzentas milhoes"},
+			},
+		},
+		{
+			name:  "Ordinal",
+			input: "vigésimo terceiro",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "23"},
+			},
+		},
+		{
+			name:  "Ordinal feminino composto",
+			input: "segunda milésima ducentésima quadragésima primeira",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2241"},
+			},
+		},
+		{
+			name:  "Ordinal de milhão",
+			input: "terceiro milionésimo quinto milésimo",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "3005000"},
+			},
+		},
+		{
+			name:  "Ordinais de escalas seguidas",
+			input: "milionésimo milésimo",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "1001000"},
+			},
+		},
+		{
+			name:  "Ordinal de mil milhões",
+			input: "milésimo milionésimo",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "1000000000"},
+			},
+		},
+		{
+			name:  "Ordinais de escalas fora de ordem",
+			input: "milionésimo bilionésimo",
+			expected: []Token{
+				{Type: TOKEN_ERROR, Value: "milionesimo bilionesimo"},
+			},
+		},
+		{
+			name:  "Ordinal de milhar repetido",
+			input: "milésimo milésimo",
+			expected: []Token{
+				{Type: TOKEN_ERROR, Value: "milesimo milesimo"},
+			},
+		},
+		{
+			name:  "Ordinais abreviados",
+			input: "1º mais 2ª",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "1"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+			},
+		},
+		{
+			name:  "Ordinal fora de ordem",
+			input: "terceiro vigésimo",
+			expected: []Token{
+				{Type: TOKEN_ERROR, Value: "vigesimo"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "3"},
+			},
+		},
+		{
+			name:  "Fração com ordinal",
+			input: "três vigésimos mais um quarto",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "0.15"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "0.25"},
+			},
+		},
+		{
+			name:  "Misplaced thousands separator",
+			input: "1.25",
+			expected: []Token{
+				{Type: TOKEN_ERROR, Value: "1.25"},
+			},
+		},
+		{
+			name:  "Scales out of order",
+			input: "um milhao dois bilhoes",
+			expected: []Token{
+				{Type: TOKEN_ERROR, Value: "um milhao dois bilhoes"},
+			},
+		},
 		{
 			name:  "Elevado",
 			input: "dois elevado por quatro",
@@ -156,3 +425,989 @@ func TestLexerParseLine(t *testing.T) {
 		})
 	}
 }
+
+func TestLexerTokens(t *testing.T) {
+	lexer := NewLexerFromReader(strings.NewReader("dois mais tres\n\ncem"))
+
+	expected := []Token{
+		{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+		{Type: TOKEN_PLUS, Value: "+"},
+		{Type: TOKEN_NUMBER_PARSED, Value: "3"},
+		{Type: TOKEN_EOL},
+		{Type: TOKEN_EOL},
+		{Type: TOKEN_NUMBER_PARSED, Value: "100"},
+		{Type: TOKEN_EOL},
+	}
+
+	i := 0
+
+	for token, err := range lexer.Tokens() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if i >= len(expected) {
+			t.Fatalf("unexpected token %v", token)
+		}
+
+		if token.Type != expected[i].Type || token.Value != expected[i].Value {
+			t.Errorf("expected token %v, got %v", expected[i], token)
+		}
+
+		i++
+	}
+
+	if i != len(expected) {
+		t.Errorf("expected %d tokens, got %d", len(expected), i)
+	}
+}
+
+func TestLexerPositions(t *testing.T) {
+	lexer := NewLexer(nil)
+	tokens, err := lexer.ParseLine("três  mil e um mais  cem")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		pos Position
+		end Position
+	}{
+		{pos: Position{Offset: 0, Line: 1, Column: 1, Word: 0}, end: Position{Offset: 15, Line: 1, Column: 15, Word: 3}},
+		{pos: Position{Offset: 16, Line: 1, Column: 16, Word: 4}, end: Position{Offset: 20, Line: 1, Column: 20, Word: 4}},
+		{pos: Position{Offset: 22, Line: 1, Column: 22, Word: 5}, end: Position{Offset: 25, Line: 1, Column: 25, Word: 5}},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+
+	for i, token := range tokens {
+		if token.Pos != expected[i].pos || token.End != expected[i].end {
+			t.Errorf("token %d: expected span %v-%v, got %v-%v", i, expected[i].pos, expected[i].end, token.Pos, token.End)
+		}
+	}
+}
+
+func TestLexerOperatorPositions(t *testing.T) {
+	tests := []struct {
+		input string
+		index int
+		pos   Position
+		end   Position
+	}{
+		{
+			input: "dez dividido por dois",
+			index: 1,
+			pos:   Position{Offset: 4, Line: 1, Column: 5, Word: 1},
+			end:   Position{Offset: 16, Line: 1, Column: 17, Word: 2},
+		},
+		{
+			input: "abre parentese dois fecha parentese",
+			index: 0,
+			pos:   Position{Offset: 0, Line: 1, Column: 1, Word: 0},
+			end:   Position{Offset: 14, Line: 1, Column: 15, Word: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			tokens, err := NewLexer(nil).ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			token := tokens[test.index]
+
+			if token.Pos != test.pos || token.End != test.end {
+				t.Errorf("expected span %v-%v, got %v-%v", test.pos, test.end, token.Pos, token.End)
+			}
+		})
+	}
+}
+
+func TestLexerRecovery(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Token
+	}{
+		{
+			name:  "Several errors",
+			input: "dois mais xyz vezes cento tres dividido dez abc mais cem",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_ERROR, Value: "xyz"},
+				{Type: TOKEN_TIMES, Value: "*"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "100"},
+				{Type: TOKEN_ERROR, Value: "tres"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "3"},
+				{Type: TOKEN_ERROR, Value: "dez"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "10"},
+				{Type: TOKEN_ERROR, Value: "abc"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "100"},
+			},
+		},
+		{
+			name:  "Number after unit",
+			input: "dois tres mais um",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+				{Type: TOKEN_ERROR, Value: "tres"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "3"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "1"},
+			},
+		},
+		{
+			name:  "Operator after cento",
+			input: "cento mais dois",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "100"},
+				{Type: TOKEN_ERROR, Value: "mais"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+			},
+		},
+		{
+			name:  "Word rejected in a phrase",
+			input: "dois dividido xyz tres",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+				{Type: TOKEN_ERROR, Value: "xyz"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "3"},
+			},
+		},
+		{
+			name:  "Error at end of line",
+			input: "dois elevado",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+				{Type: TOKEN_ERROR, Value: ""},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lexer := NewLexer(nil)
+			lexer.SetRecovery(true)
+
+			tokens, err := lexer.ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(tokens) != len(test.expected) {
+				t.Fatalf("expected %d tokens, got %d: %v", len(test.expected), len(tokens), tokens)
+			}
+
+			for i, token := range tokens {
+				if token.Type != test.expected[i].Type || token.Value != test.expected[i].Value {
+					t.Errorf("expected token %v, got %v", test.expected[i], token)
+				}
+			}
+		})
+	}
+}
+
+func TestLexerLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		locale   Locale
+		expected string
+	}{
+		{
+			name:     "Bilhao in pt-BR",
+			input:    "um bilhão",
+			locale:   PtBR,
+			expected: "1000000000",
+		},
+		{
+			name:     "Biliao in pt-BR",
+			input:    "um bilião",
+			locale:   PtBR,
+			expected: "1000000000",
+		},
+		{
+			name:     "Biliao in pt-PT",
+			input:    "um bilião",
+			locale:   PtPT,
+			expected: "1000000000000",
+		},
+		{
+			name:     "Mil milhoes",
+			input:    "mil milhões",
+			locale:   PtPT,
+			expected: "1000000000",
+		},
+		{
+			name:     "Dois mil e quinhentos milhoes",
+			input:    "dois mil e quinhentos milhões",
+			locale:   PtPT,
+			expected: "2500000000",
+		},
+		{
+			name:     "Trilioes e mil milhoes",
+			input:    "três triliões e mil milhões",
+			locale:   PtPT,
+			expected: "3000000001000000000",
+		},
+		{
+			name:     "Dezasseis",
+			input:    "dezasseis mais catorze",
+			locale:   PtPT,
+			expected: "16",
+		},
+		{
+			name:     "Bilionesimo in pt-PT",
+			input:    "um bilionésimo",
+			locale:   PtPT,
+			expected: "1/1000000000000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lexer := NewLexer(nil)
+			lexer.SetLocale(test.locale)
+
+			tokens, err := lexer.ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tokens[0].Type != TOKEN_NUMBER_PARSED || tokens[0].Rat.RatString() != test.expected {
+				t.Errorf("expected number %v, got %v", test.expected, tokens[0])
+			}
+		})
+	}
+}
+
+func TestLexerScales(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		locale   Locale
+		expected string
+	}{
+		{name: "Vigintilhao", input: "um vigintilhão", locale: PtBR, expected: "1" + strings.Repeat("0", 63)},
+		{name: "Vigintiliao in pt-PT", input: "um vigintilião", locale: PtPT, expected: "1" + strings.Repeat("0", 120)},
+		{name: "Centilhoes", input: "dois centilhões", locale: PtBR, expected: "2" + strings.Repeat("0", 303)},
+		{name: "Trescentilhao", input: "um trescentilhão", locale: PtBR, expected: "1" + strings.Repeat("0", 312)},
+		{name: "Vigintilionesima", input: "um vigintilionésimo", locale: PtBR, expected: "1/1" + strings.Repeat("0", 63)},
+		{name: "Vigintilionesimos", input: "três vigintilionésimos", locale: PtBR, expected: "3/1" + strings.Repeat("0", 63)},
+		{name: "Mil decilhoes", input: "mil decilhões", locale: PtBR, expected: "1" + strings.Repeat("0", 36)},
+		{name: "Decilhoes de decilhoes", input: "um decilhão de decilhões", locale: PtBR, expected: "1" + strings.Repeat("0", 66)},
+		{name: "Milhoes de milhoes", input: "dois milhões de milhões e cinco", locale: PtBR, expected: "2000000000005"},
+		{name: "Multiple not closed by a scale", input: "dois milhões e cinco de decilhões", locale: PtBR, expected: "2000005" + strings.Repeat("0", 33)},
+		{name: "Compound money", input: "dois milhões de milhões de reais", locale: PtBR, expected: "2000000000000"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lexer := NewLexer(nil)
+			lexer.SetLocale(test.locale)
+
+			tokens, err := lexer.ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(tokens) != 1 || tokens[0].Type != TOKEN_NUMBER_PARSED || tokens[0].Rat.RatString() != test.expected {
+				t.Errorf("expected number %v, got %v", test.expected, tokens)
+			}
+		})
+	}
+}
+
+func TestLexerMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		currency string
+		expected string
+	}{
+		{name: "Reais e centavos", input: "dez reais e cinquenta centavos", currency: "BRL", expected: "10.5"},
+		{name: "E cinquenta", input: "dez reais e cinquenta", currency: "BRL", expected: "10.5"},
+		{name: "Um real", input: "um real", currency: "BRL", expected: "1"},
+		{name: "Centavos alone", input: "noventa e nove centavos", currency: "BRL", expected: "0.99"},
+		{name: "Cento e cinquenta centavos", input: "cento e cinquenta centavos", currency: "BRL", expected: "1.5"},
+		{name: "De reais", input: "dois milhões de reais", currency: "BRL", expected: "2000000"},
+		{name: "E meio de reais", input: "um milhão e meio de reais", currency: "BRL", expected: "1500000"},
+		{name: "Mil reais", input: "mil reais e um centavo", currency: "BRL", expected: "1000.01"},
+		{name: "Decimal", input: "dez vírgula cinco reais", currency: "BRL", expected: "10.5"},
+		{name: "Zero", input: "zero reais", currency: "BRL", expected: "0"},
+		{name: "Libras", input: "duas libras e cinco pence", currency: "GBP", expected: "2.05"},
+		{name: "Not money", input: "dez", currency: "", expected: "10"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lexer := NewLexer(nil)
+
+			if test.currency != "" {
+				lexer.SetCurrency(Currencies[test.currency])
+			}
+
+			tokens, err := lexer.ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(tokens) != 1 || tokens[0].Type != TOKEN_NUMBER_PARSED {
+				t.Fatalf("expected a number, got %v", tokens)
+			}
+
+			if tokens[0].Value != test.expected || tokens[0].Currency != test.currency {
+				t.Errorf("expected %v %v, got %v %v", test.expected, test.currency, tokens[0].Value, tokens[0].Currency)
+			}
+		})
+	}
+}
+
+func TestLexerMoneyErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected ErrorCode
+	}{
+		{name: "Two amounts in reais", input: "dez reais e cinco reais", expected: ErrInvalidNumber},
+		{name: "Too many centavos", input: "dez reais e cento e vinte centavos", expected: ErrInvalidNumber},
+		{name: "Nothing after e", input: "dez reais e", expected: ErrExpectedNumber},
+		{name: "De without currency", input: "um milhão de", expected: ErrUnexpectedNumber},
+		{name: "Number after centavos", input: "dez centavos dois", expected: ErrUnexpectedNumber},
+		{name: "De before reais after a unit", input: "dez de reais", expected: ErrUnexpectedNumber},
+		{name: "Number after de", input: "um milhão de dois milhões", expected: ErrExpectedWord},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens, err := NewLexer(nil).ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			i := slices.IndexFunc(tokens, func(token Token) bool { return token.Type == TOKEN_ERROR })
+
+			if i < 0 {
+				t.Fatalf("expected an error token, got %v", tokens)
+			}
+
+			if !errors.Is(tokens[i].lexError(), test.expected) {
+				t.Errorf("expected error %q, got %v", test.expected, tokens[i].lexError())
+			}
+		})
+	}
+}
+
+func TestLexerSymbols(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []TokenType
+	}{
+		{
+			name:     "Glued symbols",
+			input:    "(dez+cinco)*2",
+			expected: []TokenType{TOKEN_LEFT_BRACKET, TOKEN_NUMBER_PARSED, TOKEN_PLUS, TOKEN_NUMBER_PARSED, TOKEN_RIGHT_BRACKET, TOKEN_TIMES, TOKEN_NUMBER_PARSED},
+		},
+		{
+			name:     "Symbols mixed with words",
+			input:    "3! mais dois x tres ^ 2 % 5 - um / 1",
+			expected: []TokenType{TOKEN_NUMBER_PARSED, TOKEN_FACTORIAL, TOKEN_PLUS, TOKEN_NUMBER_PARSED, TOKEN_TIMES, TOKEN_NUMBER_PARSED, TOKEN_POWER, TOKEN_NUMBER_PARSED, TOKEN_MOD, TOKEN_NUMBER_PARSED, TOKEN_MINUS, TOKEN_NUMBER_PARSED, TOKEN_DIVIDE, TOKEN_NUMBER_PARSED},
+		},
+		{
+			name:     "Same stream as the words",
+			input:    "abre parentese dez mais cinco fecha parentese vezes dois",
+			expected: []TokenType{TOKEN_LEFT_BRACKET, TOKEN_NUMBER_PARSED, TOKEN_PLUS, TOKEN_NUMBER_PARSED, TOKEN_RIGHT_BRACKET, TOKEN_TIMES, TOKEN_NUMBER_PARSED},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lexer := NewLexer(nil)
+			lexer.SetSymbols(true)
+
+			tokens, err := lexer.ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(tokens) != len(test.expected) {
+				t.Fatalf("expected %d tokens, got %d: %v", len(test.expected), len(tokens), tokens)
+			}
+
+			for i, token := range tokens {
+				if token.Type != test.expected[i] {
+					t.Errorf("expected token type %v, got %v", test.expected[i], token.Type)
+				}
+			}
+		})
+	}
+
+	tokens, _ := NewLexer(nil).ParseLine("dez+cinco")
+
+	if len(tokens) != 1 || tokens[0].Type != TOKEN_ERROR {
+		t.Errorf("expected symbols to be rejected by default, got %v", tokens)
+	}
+}
+
+func TestLexerOperatorPhrases(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []Token
+	}{
+		{
+			input: "dez multiplicado por dois",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "10"},
+				{Type: TOKEN_TIMES, Value: "*"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+			},
+		},
+		{
+			input: "dez sobre dois",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "10"},
+				{Type: TOKEN_DIVIDE, Value: "/"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+			},
+		},
+		{
+			input: "dois elevado à quinta",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+				{Type: TOKEN_POWER, Value: "^"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "5"},
+			},
+		},
+		{
+			input: "dois elevado a cinco",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+				{Type: TOKEN_POWER, Value: "^"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "5"},
+			},
+		},
+		{
+			input: "dez ao quadrado mais um",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "10"},
+				{Type: TOKEN_POWER, Value: "^"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "2"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "1"},
+			},
+		},
+		{
+			input: "tres elevado ao cubo",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "3"},
+				{Type: TOKEN_POWER, Value: "^"},
+				{Type: TOKEN_NUMBER_PARSED, Value: "3"},
+			},
+		},
+		{
+			input: "dez ao dobro",
+			expected: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Value: "10"},
+				{Type: TOKEN_ERROR, Value: "dobro"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			tokens, err := NewLexer(nil).ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(tokens) != len(test.expected) {
+				t.Fatalf("expected %d tokens, got %d: %v", len(test.expected), len(tokens), tokens)
+			}
+
+			for i, token := range tokens {
+				if token.Type != test.expected[i].Type || token.Value != test.expected[i].Value {
+					t.Errorf("expected token %v, got %v", test.expected[i], token)
+				}
+			}
+		})
+	}
+}
+
+func TestOperatorPhrasesArePrefixFree(t *testing.T) {
+	for _, phrase := range operatorPhrases {
+		for i := 1; i < len(phrase.words); i++ {
+			if complete, _ := matchPhrases(phrase.words[:i]); complete != nil {
+				t.Errorf("phrase %v is a prefix of %v", complete.words, phrase.words)
+			}
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	// The three-stage normalisation fold replaces
+	chain := func(s string) string {
+		folded, _, _ := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+
+		return strings.ToLower(folded)
+	}
+
+	for _, input := range []string{
+		"",
+		"dez",
+		"Dez",
+		"MILHÃO",
+		"três",
+		"parêntese",
+		"vírgula",
+		"Ç",
+		"a\u0301gua",
+		"ǖ",
+		"Ångström",
+		"İ",
+		"한국어",
+		"vinte e três",
+		"2º",
+		"1ª",
+	} {
+		if actual, expected := fold(input, true), chain(input); actual != expected {
+			t.Errorf("fold(%q): expected %q, got %q", input, expected, actual)
+		}
+	}
+
+	if actual := removeAccents("Milhão"); actual != "Milhao" {
+		t.Errorf("removeAccents: expected %q, got %q", "Milhao", actual)
+	}
+}
+
+func TestLexerSharedVocabulary(t *testing.T) {
+	// VerifyCheque reads "hum" with a lexer of its own
+	if _, err := VerifyCheque(big.NewRat(1, 1), "hum real"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done := make(chan error)
+
+	for i := range 8 {
+		go func() {
+			lexer := NewLexerFromReader(strings.NewReader(""))
+
+			if i%2 == 1 {
+				lexer.SetLocale(PtPT)
+				lexer.SetCurrency(Currencies["EUR"])
+			}
+
+			tokens, err := lexer.ParseLine("hum bilhao")
+
+			if err == nil && (len(tokens) == 0 || tokens[0].Type != TOKEN_ERROR || tokens[0].Value != "hum") {
+				err = errors.New("hum read outside of VerifyCheque")
+			}
+
+			done <- err
+		}()
+	}
+
+	for range 8 {
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	}
+
+	if _, ok := NewLexerFromReader(strings.NewReader("")).wordState("euros"); ok {
+		t.Error("currency of another lexer read")
+	}
+}
+
+// transcript is a line as produced by speech recognition, with accents, upper
+// case and words outside the vocabulary.
+const transcript = "Então dá Dois Milhões Trezentos e Quarenta e Cinco Mil Seiscentos e Setenta e Oito vezes abre parêntese vinte e três mais quatro fecha parêntese dividido por cento e vinte e um"
+
+// BenchmarkNewLexer compares the lexers sharing the vocabulary of the locale
+// with building it for each lexer, as NewLexer formerly did.
+func BenchmarkNewLexer(b *testing.B) {
+	b.Run("shared", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			NewLexerFromReader(strings.NewReader(""))
+		}
+	})
+
+	b.Run("rebuilt", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			newVocabulary(PtBR)
+		}
+	})
+}
+
+// BenchmarkLexerNormalise compares fold with the three-stage transform chain
+// followed by strings.ToLower, which splitWords formerly ran on each word.
+func BenchmarkLexerNormalise(b *testing.B) {
+	words := strings.Fields(transcript)
+
+	b.Run("fold", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			for _, word := range words {
+				fold(word, true)
+			}
+		}
+	})
+
+	b.Run("chain", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+			for _, word := range words {
+				lexeme, _, _ := transform.String(t, word)
+				strings.ToLower(lexeme)
+			}
+		}
+	})
+}
+
+// legacyLexer is the lexer as it was before its vocabulary was shared, kept
+// to measure ParseLine against. It builds a dictionary of its own, normalises
+// the words with the three-stage transform chain, looks each word up every
+// time it is read and sends its log to io.Discard. The automaton and the
+// values of the numbers are those of Lexer.
+type legacyLexer struct {
+	Lexer
+}
+
+func newLegacyLexer() *legacyLexer {
+	lexer := &legacyLexer{Lexer: Lexer{reader: bufio.NewReader(strings.NewReader("")), locale: PtBR}}
+	lexer.numberDict = maps.Clone(numberWords)
+
+	for word, val := range ordinalWords {
+		addOrdinal(lexer.numberDict, word, val)
+	}
+
+	for rank, scale := range Scales {
+		value := PtBR.scaleValue(rank).String()
+
+		for _, word := range scale.words() {
+			lexer.numberDict[legacyRemoveAccents(word)] = numberState{class: classScale, value: value}
+		}
+
+		for _, word := range scale.ordinals() {
+			addOrdinal(lexer.numberDict, legacyRemoveAccents(word), numberState{class: classOrdinalScale, value: value})
+		}
+	}
+
+	lexer.SetCurrency(Currencies["BRL"])
+
+	return lexer
+}
+
+func legacyRemoveAccents(s string) string {
+	result, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+
+	if err != nil {
+		return s
+	}
+
+	return result
+}
+
+func legacySplitWords(rawLine string, lineNumber int) []word {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+	words := make([]word, 0, 16)
+
+	start := -1
+	startColumn := 0
+	column := 1
+
+	flush := func(offset int) {
+		if start < 0 {
+			return
+		}
+
+		lexeme, _, _ := transform.String(t, rawLine[start:offset])
+
+		words = append(words, word{
+			lexeme: strings.ToLower(lexeme),
+			pos:    Position{Offset: start, Line: lineNumber, Column: startColumn, Word: len(words)},
+			end:    Position{Offset: offset, Line: lineNumber, Column: column, Word: len(words)},
+		})
+
+		start = -1
+	}
+
+	for offset, r := range rawLine {
+		if unicode.IsSpace(r) {
+			flush(offset)
+		} else if start < 0 {
+			start, startColumn = offset, column
+		}
+
+		column++
+	}
+
+	flush(len(rawLine))
+
+	return words
+}
+
+func (l *legacyLexer) classify(lexeme string) (wordClass, numberState) {
+	if complete, next := matchPhrases([]string{lexeme}); complete != nil || len(next) > 0 {
+		return classOperator, numberState{}
+	}
+
+	if val, ok := l.lookup(lexeme); ok {
+		return val.class, val
+	}
+
+	return classOther, numberState{}
+}
+
+func (l *legacyLexer) closedBy(words []word, classes []wordClass) bool {
+	for _, w := range words {
+		class, _ := l.classify(w.lexeme)
+
+		if slices.Contains(classes, class) {
+			return true
+		}
+
+		if !class.isNumber() {
+			return false
+		}
+	}
+
+	return false
+}
+
+func (l *legacyLexer) resync(words []word, index int) int {
+	for ; index < len(words); index++ {
+		if class, _ := l.classify(words[index].lexeme); stateStart.transition(class).action != actionError {
+			return index
+		}
+	}
+
+	return index
+}
+
+func (l *legacyLexer) numberToken(numberTokens []Token) Token {
+	log.Println(numberTokens)
+
+	for _, token := range numberTokens {
+		log.Println(token)
+	}
+
+	return l.getNumberTokenFromList(numberTokens)
+}
+
+// ParseLine is Lexer.parseLine as it was, with the same recovery.
+func (l *legacyLexer) ParseLine(rawLine string) []Token {
+	words := legacySplitWords(rawLine, 1)
+
+	defer log.SetOutput(log.Writer())
+
+	log.SetOutput(io.Discard)
+
+	tokens := make([]Token, 0, 64)
+
+	eol := word{pos: endOfLine(rawLine, 1, len(words))}
+	eol.end = eol.pos
+
+	index := 0
+	state := stateStart
+
+	operatorStart := eol
+	phrase := []string{}
+
+	numberTokens := make([]Token, 0)
+
+	for {
+		current := eol
+
+		if index < len(words) {
+			current = words[index]
+		}
+
+		lexeme := current.lexeme
+
+		if lexeme == "" && state == stateStart {
+			break
+		}
+
+		start := current
+
+		if state == statePhrase {
+			start = operatorStart
+		}
+
+		if state == stateStart && len(numberTokens) > 0 {
+			tokens = append(tokens, l.numberToken(numberTokens))
+
+			numberTokens = make([]Token, 0, len(numberTokens)+1)
+		}
+
+		wordIndex, tokenCount, from := index, len(tokens), state
+
+		class, val := l.classify(lexeme)
+		transition := state.transition(class)
+
+		if transition.lookahead != nil && !l.closedBy(words[index+1:], transition.lookahead) {
+			transition = lexTable[state].otherNumber
+		}
+
+		switch transition.action {
+		case actionPush:
+			if transition.split {
+				numberTokens = append(numberTokens, Token{Type: TOKEN_DIVIDE, Value: symbolValues[TOKEN_DIVIDE]})
+			}
+
+			numberTokens = append(numberTokens, Token{Type: TOKEN_NUMBER, Value: val.value, Spell: lexeme})
+			state = transition.next
+		case actionSkip:
+			state = transition.next
+		case actionFinish:
+			state = stateStart
+			index--
+		case actionOperator:
+			if state == stateStart {
+				operatorStart, phrase = current, phrase[:0]
+			}
+
+			state, tokens = l.readOperator(phrase, lexeme, tokens)
+			phrase = append(phrase, lexeme)
+		case actionError:
+			tokens = append(tokens, newErrorToken(transition.code, lexeme, transition.errorMessage(lexeme), transition.expected...))
+			state = stateStart
+		}
+
+		locate(tokens, start, current)
+		locate(numberTokens, start, current)
+
+		if l.recovery && hasError(tokens[tokenCount:]) {
+			errorTokens := slices.Clone(tokens[tokenCount:])
+			tokens = tokens[:tokenCount]
+
+			if len(numberTokens) > 0 {
+				tokens = append(tokens, l.numberToken(numberTokens))
+
+				numberTokens = make([]Token, 0, len(numberTokens)+1)
+			}
+
+			tokens = append(tokens, errorTokens...)
+
+			if from == stateStart {
+				wordIndex++
+			}
+
+			index, state = l.resync(words, wordIndex), stateStart
+
+			continue
+		}
+
+		if !l.recovery && len(tokens) > 0 && tokens[len(tokens)-1].Type == TOKEN_ERROR {
+			break
+		}
+
+		index++
+	}
+
+	if len(numberTokens) > 0 {
+		tokens = append(tokens, l.numberToken(numberTokens))
+	}
+
+	return tokens
+}
+
+func TestLegacyLexer(t *testing.T) {
+	for _, input := range []string{
+		transcript,
+		"fatorial de trinta vezes abre parentese fatorial de oito mais quatro decilhoes fecha parentese",
+		"dez reais e cinquenta centavos vezes dois mais um milhão de reais",
+		"três vinte avos mais dois milhões e cinco de decilhões",
+		"dois mais xyz vezes cento tres dividido dez abc mais cem",
+	} {
+		for _, recovery := range []bool{false, true} {
+			lexer, legacy := NewLexerFromReader(strings.NewReader("")), newLegacyLexer()
+			lexer.SetRecovery(recovery)
+			legacy.SetRecovery(recovery)
+
+			tokens, _ := lexer.ParseLine(input)
+
+			if expected := legacy.ParseLine(input); !slices.EqualFunc(tokens, expected, func(a, b Token) bool {
+				return a.Type == b.Type && a.Value == b.Value && a.Pos == b.Pos && a.End == b.End
+			}) {
+				t.Errorf("%q: expected %v, got %v", input, expected, tokens)
+			}
+		}
+	}
+}
+
+// BenchmarkLexerParseLine compares Lexer.ParseLine with the former lexer.
+func BenchmarkLexerParseLine(b *testing.B) {
+	b.Run("shared", func(b *testing.B) {
+		lexer := NewLexerFromReader(strings.NewReader(""))
+		lexer.SetRecovery(true)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			lexer.ParseLine(transcript)
+		}
+	})
+
+	b.Run("legacy", func(b *testing.B) {
+		lexer := newLegacyLexer()
+		lexer.SetRecovery(true)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			lexer.ParseLine(transcript)
+		}
+	})
+}
+
+// BenchmarkLexerTranscripts lexes each transcript with a lexer of its own, as
+// a server handling one request per transcript does, with Lexer and with the
+// former lexer.
+func BenchmarkLexerTranscripts(b *testing.B) {
+	b.Run("shared", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			lexer := NewLexerFromReader(strings.NewReader(""))
+			lexer.SetRecovery(true)
+			lexer.ParseLine(transcript)
+		}
+	})
+
+	b.Run("legacy", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			lexer := newLegacyLexer()
+			lexer.SetRecovery(true)
+			lexer.ParseLine(transcript)
+		}
+	})
+}
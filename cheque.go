@@ -0,0 +1,165 @@
+package spellnumber
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// chequeFillers are the characters written around the amount of a cheque so
+// it cannot be extended, as in "***mil reais***" or "mil reais ----".
+const chequeFillers = "*-–—="
+
+// ChequeVerification is the outcome of VerifyCheque.
+type ChequeVerification struct {
+	// Match is set when the written amount is worth the numeric one
+	Match bool
+	// Amount is the numeric amount rounded to the centavo and Written the
+	// value of the written one, nil when it cannot be read
+	Amount  *big.Rat
+	Written *big.Rat
+	// Expected is the spelling of the numeric amount
+	Expected string
+	// Mismatches holds, when the amounts disagree, a TOKEN_ERROR token for
+	// each written word missing from Expected and for each word of Expected
+	// missing from the written amount, located where it should be. They can
+	// be shown with RenderDiagnostic.
+	Mismatches []Token
+}
+
+// VerifyCheque checks the amount written on a cheque against its numeric
+// amount, in reais. The written amount is read by the lexer and the parser,
+// accepting the conventions of cheques: the archaic "hum", the asterisks and
+// dashes filling the line, "reais" and "centavos", and line breaks. The error
+// reports a written amount that cannot be read; the verification is returned
+// along with it.
+func VerifyCheque(amount *big.Rat, written string) (ChequeVerification, error) {
+	speller := NewSpeller()
+
+	verification := ChequeVerification{
+		Amount:   new(big.Rat).SetFrac(roundRat(new(big.Rat).Mul(amount, big.NewRat(100, 1))), big.NewInt(100)),
+		Expected: speller.SpellCurrency(amount),
+	}
+
+	text := clearChequeFillers(written)
+
+	lexer := NewLexerFromReader(strings.NewReader(""))
+	lexer.localDict["hum"], _ = lexer.wordState("um")
+
+	tokens, err := lexer.ParseLine(text)
+
+	if err == nil {
+		verification.Written, err = NewParser(tokens).ParseRat()
+	}
+
+	verification.Match = err == nil && verification.Written.Cmp(verification.Amount) == 0
+
+	if !verification.Match {
+		words := splitWords(text, 1, false)
+
+		verification.Mismatches = chequeMismatches(written, words, strings.Fields(removeAccents(verification.Expected)))
+	}
+
+	return verification, err
+}
+
+// clearChequeFillers blanks out the filler characters of text, keeping the
+// offsets of the other characters.
+func clearChequeFillers(text string) string {
+	builder := strings.Builder{}
+
+	for _, r := range text {
+		if strings.ContainsRune(chequeFillers, r) {
+			builder.WriteString(strings.Repeat(" ", utf8.RuneLen(r)))
+			continue
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// chequeMismatches compares the written words with the expected ones, word by
+// word along their longest common subsequence, and returns a token for each
+// word out of it. "hum" is taken for "um". The connector "e" is left out of
+// the comparison, as cheques write "mil duzentos" as well as "mil e
+// duzentos".
+func chequeMismatches(text string, words []word, expected []string) []Token {
+	count := len(words)
+
+	words = slices.DeleteFunc(slices.Clone(words), func(w word) bool { return w.lexeme == "e" })
+	expected = slices.DeleteFunc(slices.Clone(expected), func(w string) bool { return w == "e" })
+
+	lexemes := make([]string, len(words))
+
+	for i, w := range words {
+		lexemes[i] = w.lexeme
+
+		if lexemes[i] == "hum" {
+			lexemes[i] = "um"
+		}
+	}
+
+	// common[i][j] is the length of the longest common subsequence of
+	// lexemes[i:] and expected[j:]
+	common := make([][]int, len(lexemes)+1)
+
+	for i := range common {
+		common[i] = make([]int, len(expected)+1)
+	}
+
+	for i := len(lexemes) - 1; i >= 0; i-- {
+		for j := len(expected) - 1; j >= 0; j-- {
+			if lexemes[i] == expected[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	mismatches := make([]Token, 0)
+
+	i, j := 0, 0
+
+	for i < len(lexemes) || j < len(expected) {
+		switch {
+		case i < len(lexemes) && j < len(expected) && lexemes[i] == expected[j]:
+			i, j = i+1, j+1
+		case j < len(expected) && (i == len(lexemes) || common[i][j+1] >= common[i+1][j]):
+			offset, index := len(text), count
+
+			if i < len(lexemes) {
+				offset, index = words[i].pos.Offset, words[i].pos.Word
+			}
+
+			pos := positionAt(text, offset, index)
+
+			mismatches = append(mismatches, Token{Type: TOKEN_ERROR, Value: expected[j], Spell: fmt.Sprintf("Esperado '%s'", expected[j]), Pos: pos, End: pos})
+			j++
+		default:
+			mismatches = append(mismatches, Token{
+				Type:  TOKEN_ERROR,
+				Value: words[i].lexeme,
+				Spell: fmt.Sprintf("Palavra '%s' não confere com o valor", words[i].lexeme),
+				Pos:   positionAt(text, words[i].pos.Offset, words[i].pos.Word),
+				End:   positionAt(text, words[i].end.Offset, words[i].pos.Word),
+			})
+			i++
+		}
+	}
+
+	return mismatches
+}
+
+// positionAt locates the byte offset of text, counting its lines, for the
+// word with the given index. The offset of the position is within its line.
+func positionAt(text string, offset int, index int) Position {
+	line := 1 + strings.Count(text[:offset], "\n")
+	lineStart := strings.LastIndex(text[:offset], "\n") + 1
+
+	return Position{Offset: offset - lineStart, Line: line, Column: utf8.RuneCountInString(text[lineStart:offset]) + 1, Word: index}
+}
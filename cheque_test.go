@@ -0,0 +1,122 @@
+package spellnumber
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestVerifyCheque(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     string
+		written    string
+		match      bool
+		mismatches []string
+	}{
+		{
+			name:    "Hum and fillers",
+			amount:  "1200",
+			written: "***Hum mil e duzentos reais---",
+			match:   true,
+		},
+		{
+			name:    "Reais and centavos",
+			amount:  "1234.56",
+			written: "mil e duzentos e trinta e quatro reais e cinquenta e seis centavos",
+			match:   true,
+		},
+		{
+			name:    "Line break",
+			amount:  "1234.56",
+			written: "** mil e duzentos e trinta e quatro reais\ne cinquenta e seis centavos **",
+			match:   true,
+		},
+		{
+			name:    "Without reais",
+			amount:  "15",
+			written: "quinze",
+			match:   true,
+		},
+		{
+			name:       "Wrong word",
+			amount:     "1234",
+			written:    "mil e duzentos e trinta e cinco reais",
+			mismatches: []string{"quatro", "cinco"},
+		},
+		{
+			name:       "Missing word",
+			amount:     "1234",
+			written:    "mil e duzentos e quatro reais",
+			mismatches: []string{"trinta"},
+		},
+		{
+			name:       "Without the connector",
+			amount:     "1234",
+			written:    "mil duzentos e trinta e cinco reais",
+			mismatches: []string{"quatro", "cinco"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, _ := new(big.Rat).SetString(tt.amount)
+
+			verification, err := VerifyCheque(amount, tt.written)
+
+			if err != nil {
+				t.Fatalf("VerifyCheque(%s, %q) error: %v", tt.amount, tt.written, err)
+			}
+
+			if verification.Match != tt.match {
+				t.Errorf("VerifyCheque(%s, %q).Match = %v, want %v", tt.amount, tt.written, verification.Match, tt.match)
+			}
+
+			words := make([]string, 0)
+
+			for _, mismatch := range verification.Mismatches {
+				words = append(words, mismatch.Value)
+			}
+
+			if !slices.Equal(words, tt.mismatches) && !(len(words) == 0 && len(tt.mismatches) == 0) {
+				t.Errorf("VerifyCheque(%s, %q).Mismatches = %v, want %v", tt.amount, tt.written, words, tt.mismatches)
+			}
+		})
+	}
+}
+
+func TestVerifyChequeUnreadable(t *testing.T) {
+	verification, err := VerifyCheque(big.NewRat(12, 1), "doze xyz reais")
+
+	if err == nil {
+		t.Fatalf("VerifyCheque(12, %q) should fail", "doze xyz reais")
+	}
+
+	if verification.Match || verification.Written != nil {
+		t.Errorf("VerifyCheque(12, %q) = %+v, want no written value", "doze xyz reais", verification)
+	}
+
+	if len(verification.Mismatches) != 1 || verification.Mismatches[0].Value != "xyz" || verification.Mismatches[0].Pos.Column != 6 {
+		t.Errorf("VerifyCheque(12, %q).Mismatches = %+v, want 'xyz' at column 6", "doze xyz reais", verification.Mismatches)
+	}
+}
+
+func TestVerifyChequeLines(t *testing.T) {
+	verification, _ := VerifyCheque(big.NewRat(21, 1), "vinte reais\ne dois")
+
+	if len(verification.Mismatches) == 0 {
+		t.Fatalf("VerifyCheque(21, ...) should report mismatches")
+	}
+
+	last := verification.Mismatches[len(verification.Mismatches)-1]
+
+	if last.Pos.Line != 2 {
+		t.Errorf("VerifyCheque(21, ...) last mismatch %+v, want it on line 2", last)
+	}
+
+	expected := "linha 2, coluna 3: Palavra 'dois' não confere com o valor\ne dois\n  ^~~~"
+
+	if actual := RenderDiagnostic("e dois", last); actual != expected {
+		t.Errorf("RenderDiagnostic(line 2, %+v) = %q, want %q", last, actual, expected)
+	}
+}
@@ -4,31 +4,62 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"math/big"
+	"os"
+	"slices"
 
 	spellnumber "github.com/josecleiton/spellnumber"
 )
 
 var verboseFlag bool
+var diagramFlag string
+var decimalFlag string
+var feminineFlag bool
+var localeFlag string
+var asciiFlag bool
+var currencyFlag string
 
 func init() {
 	flag.BoolVar(&verboseFlag, "v", false, "verbose output")
+	flag.StringVar(&diagramFlag, "diagram", "", "print the lexer diagram (dot or mermaid) and exit")
+	flag.StringVar(&decimalFlag, "decimal", "comma", "spelling of decimals (comma or fraction)")
+	flag.BoolVar(&feminineFlag, "feminine", false, "spell integers in the feminine")
+	flag.StringVar(&currencyFlag, "currency", "", "read money and spell the result in the currency with this ISO 4217 code (BRL, USD, EUR...)")
+	flag.BoolVar(&asciiFlag, "ascii", false, "spell without accents")
+	flag.StringVar(&localeFlag, "locale", "pt-BR", "variant of Portuguese (pt-BR or pt-PT)")
 
 	flag.Parse()
 }
 
 func main() {
-	lexer := spellnumber.NewLexer(nil)
+	lexer := spellnumber.NewLexerFromReader(os.Stdin)
 	lexer.SetVerbose(verboseFlag)
+	lexer.SetLocale(locale())
+	lexer.SetCurrency(currency())
 
-	for {
-		tokens, err := lexer.NextLine()
+	if diagramFlag != "" {
+		writeDiagram(lexer)
+		return
+	}
+
+	speller := spellnumber.NewSpeller()
+	speller.SetVerbose(verboseFlag)
+	speller.SetDecimalStyle(decimalStyle())
+	speller.SetLocale(locale())
+	speller.SetASCII(asciiFlag)
+	speller.SetCurrency(currency())
 
+	if feminineFlag {
+		speller.SetGender(spellnumber.Feminine)
+	}
+
+	for tokens, err := range lexer.Lines() {
 		if err != nil {
 			log.Fatalf("Lexer Error: %v\n", err)
 		}
 
-		if len(tokens) == 0 {
-			// EOF
+		// An empty line or "q" ends the session
+		if len(tokens) == 0 || len(tokens) == 1 && tokens[0].Type == spellnumber.TOKEN_ERROR && tokens[0].Value == "q" {
 			return
 		}
 
@@ -37,17 +68,109 @@ func main() {
 		parser := spellnumber.NewParser(tokens)
 		parser.SetVerbose(verboseFlag)
 
-		result, err := parser.Parse()
+		// Spoken money amounts, as in "dez reais e cinquenta centavos"
+		if slices.ContainsFunc(tokens, func(token spellnumber.Token) bool { return token.Currency != "" }) {
+			money, err := parser.ParseMoney()
+
+			if err != nil {
+				log.Fatalf("Parser Error: %v\n", err)
+			}
+
+			fmt.Printf("Result: %v\n", money)
+			fmt.Printf("Spell: %v\n", speller.SpellMoney(money))
+
+			continue
+		}
+
+		result, err := parser.ParseRat()
 
 		if err != nil {
 			log.Fatalf("Parser Error: %v\n", err)
 		}
 
-		fmt.Printf("Result: %v\n", result)
+		fmt.Printf("Result: %v\n", result.RatString())
+
+		// Integers are streamed, their spelling may be too long to hold
+		if result.IsInt() && currencyFlag == "" {
+			fmt.Print("Spell: ")
+
+			if err := speller.SpellTo(os.Stdout, result.Num()); err != nil {
+				log.Fatalf("Spell Error: %v\n", err)
+			}
+
+			fmt.Println()
+
+			continue
+		}
+
+		fmt.Printf("Spell: %v\n", spell(speller, result))
+	}
+}
+
+// spell reads results as decimals, or as fractions when they have no finite
+// decimal expansion, such as 1/3, unless -currency asks for money.
+func spell(speller *spellnumber.Speller, result *big.Rat) string {
+	if currencyFlag != "" {
+		return speller.SpellCurrency(result)
+	}
+
+	return speller.SpellDecimal(result)
+}
+
+func decimalStyle() spellnumber.DecimalStyle {
+	switch decimalFlag {
+	case "comma":
+		return spellnumber.DecimalComma
+	case "fraction":
+		return spellnumber.DecimalFraction
+	}
+
+	log.Fatalf("Unknown decimal style %q, use comma or fraction\n", decimalFlag)
+
+	return spellnumber.DecimalComma
+}
+
+// currency returns the currency selected by -currency, BRL by default.
+func currency() spellnumber.Currency {
+	if currencyFlag == "" {
+		return spellnumber.Currencies["BRL"]
+	}
+
+	currency, ok := spellnumber.Currencies[currencyFlag]
+
+	if !ok {
+		log.Fatalf("Unknown currency %q\n", currencyFlag)
+	}
+
+	return currency
+}
+
+func locale() spellnumber.Locale {
+	switch localeFlag {
+	case "pt-BR":
+		return spellnumber.PtBR
+	case "pt-PT":
+		return spellnumber.PtPT
+	}
 
-		speller := spellnumber.NewSpeller()
-		speller.SetVerbose(verboseFlag)
+	log.Fatalf("Unknown locale %q, use pt-BR or pt-PT\n", localeFlag)
+
+	return spellnumber.PtBR
+}
+
+func writeDiagram(lexer *spellnumber.Lexer) {
+	var err error
+
+	switch diagramFlag {
+	case "dot":
+		err = lexer.WriteDOT(os.Stdout)
+	case "mermaid":
+		err = lexer.WriteMermaid(os.Stdout)
+	default:
+		log.Fatalf("Unknown diagram format %q, use dot or mermaid\n", diagramFlag)
+	}
 
-		fmt.Printf("Spell: %v\n", speller.Spell(result))
+	if err != nil {
+		log.Fatalf("Diagram Error: %v\n", err)
 	}
 }
@@ -0,0 +1,174 @@
+package spellnumber
+
+import (
+	"math/big"
+	"strings"
+)
+
+// CurrencyUnit is the name of a unit of a currency, singular and plural, with
+// the gender the amount agrees with, as in "duas libras".
+type CurrencyUnit = Noun
+
+// Currency is a currency spelled by SpellCurrency: its major unit, its minor
+// unit and the number of digits of the minor unit, 0 for currencies without
+// one.
+type Currency struct {
+	Code        string
+	Major       CurrencyUnit
+	Minor       CurrencyUnit
+	MinorDigits int
+}
+
+// Currencies is the registry of the currencies by ISO 4217 code. Other
+// currencies can be added to it, or passed to Speller.SetCurrency directly.
+var Currencies = map[string]Currency{
+	"BRL": {
+		Code:        "BRL",
+		Major:       CurrencyUnit{"real", "reais", Masculine},
+		Minor:       CurrencyUnit{"centavo", "centavos", Masculine},
+		MinorDigits: 2,
+	},
+	"USD": {
+		Code:        "USD",
+		Major:       CurrencyUnit{"dólar", "dólares", Masculine},
+		Minor:       CurrencyUnit{"centavo", "centavos", Masculine},
+		MinorDigits: 2,
+	},
+	"EUR": {
+		Code:        "EUR",
+		Major:       CurrencyUnit{"euro", "euros", Masculine},
+		Minor:       CurrencyUnit{"cêntimo", "cêntimos", Masculine},
+		MinorDigits: 2,
+	},
+	"GBP": {
+		Code:        "GBP",
+		Major:       CurrencyUnit{"libra", "libras", Feminine},
+		Minor:       CurrencyUnit{"pêni", "pence", Masculine},
+		MinorDigits: 2,
+	},
+	"ARS": {
+		Code:        "ARS",
+		Major:       CurrencyUnit{"peso", "pesos", Masculine},
+		Minor:       CurrencyUnit{"centavo", "centavos", Masculine},
+		MinorDigits: 2,
+	},
+	"JPY": {
+		Code:        "JPY",
+		Major:       CurrencyUnit{"iene", "ienes", Masculine},
+		MinorDigits: 0,
+	},
+	"KWD": {
+		Code:        "KWD",
+		Major:       CurrencyUnit{"dinar", "dinares", Masculine},
+		Minor:       CurrencyUnit{"fils", "fils", Masculine},
+		MinorDigits: 3,
+	},
+}
+
+// Money is an amount in the currency with the code Currency, as read by
+// Parser.ParseMoney.
+type Money struct {
+	Amount   *big.Rat
+	Currency string
+}
+
+// String formats the amount with the digits of the minor unit of its currency,
+// when registered in Currencies, as in "31.50 BRL".
+func (m Money) String() string {
+	if currency, ok := Currencies[m.Currency]; ok {
+		return m.Amount.FloatString(currency.MinorDigits) + " " + m.Currency
+	}
+
+	return ratString(m.Amount) + " " + m.Currency
+}
+
+// SpellCurrency spells amount in the currency set by SetCurrency, reais and
+// centavos by default, as in "dois mil reais e cinquenta centavos" or "um
+// milhao de reais". The amount is rounded to the minor unit, halves away from
+// zero.
+func (s Speller) SpellCurrency(amount *big.Rat) string {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(s.currency.MinorDigits)), nil)
+
+	return s.SpellCents(roundRat(new(big.Rat).Mul(amount, new(big.Rat).SetInt(scale))))
+}
+
+// SpellMoney spells money in its currency, when registered in Currencies, or
+// in the one set by SetCurrency otherwise.
+func (s Speller) SpellMoney(money Money) string {
+	if currency, ok := Currencies[money.Currency]; ok {
+		s.currency = currency
+	}
+
+	return s.SpellCurrency(money.Amount)
+}
+
+// SpellCents spells an amount given in the minor unit of the currency, as
+// SpellCurrency.
+func (s Speller) SpellCents(cents *big.Int) string {
+	builder := strings.Builder{}
+
+	if cents.Sign() < 0 {
+		builder.WriteString(s.negative)
+		builder.WriteString(" ")
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(s.currency.MinorDigits)), nil)
+	major, minor := new(big.Int).QuoRem(new(big.Int).Abs(cents), scale, new(big.Int))
+
+	if major.Sign() == 0 && minor.Sign() == 0 {
+		builder.WriteString(s.numbers[-1])
+		builder.WriteString(" ")
+		builder.WriteString(s.currency.Major.Singular)
+
+		return s.output(builder.String())
+	}
+
+	if major.Sign() > 0 {
+		s.writeNoun(&builder, major, s.currency.Major)
+	}
+
+	if minor.Sign() > 0 {
+		if major.Sign() > 0 {
+			builder.WriteString(" ")
+			builder.WriteString(s.and)
+			builder.WriteString(" ")
+		}
+
+		s.writeNoun(&builder, minor, s.currency.Minor)
+	}
+
+	return s.output(builder.String())
+}
+
+// endsInScale reports whether the spelling of a positive number ends in a
+// scale name, such as "milhoes", rather than in a unit or in "mil".
+func (s Speller) endsInScale(number *big.Int) bool {
+	var buf [20]byte
+
+	digits := numberDigits(buf[:0], number)
+	order := 0
+
+	for tripletAt(digits, order) == 0 {
+		order++
+	}
+
+	rank, _ := s.rank(order)
+
+	return rank > 0
+}
+
+// roundRat rounds r to the nearest integer, halves away from zero.
+func roundRat(r *big.Rat) *big.Int {
+	numerator := new(big.Int).Abs(r.Num())
+	denominator := r.Denom()
+
+	// (2|n| + d) / 2d
+	rounded := numerator.Add(numerator.Lsh(numerator, 1), denominator)
+	rounded.Quo(rounded, new(big.Int).Lsh(denominator, 1))
+
+	if r.Sign() < 0 {
+		rounded.Neg(rounded)
+	}
+
+	return rounded
+}
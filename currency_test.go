@@ -0,0 +1,302 @@
+package spellnumber
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSpellerSpellCurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Reais e centavos",
+			input:    "1234.56",
+			expected: "mil e duzentos e trinta e quatro reais e cinquenta e seis centavos",
+		},
+		{
+			name:     "Um real",
+			input:    "1",
+			expected: "um real",
+		},
+		{
+			name:     "Dois reais",
+			input:    "2",
+			expected: "dois reais",
+		},
+		{
+			name:     "Um real e um centavo",
+			input:    "1.01",
+			expected: "um real e um centavo",
+		},
+		{
+			name:     "Centavos alone",
+			input:    "0.5",
+			expected: "cinquenta centavos",
+		},
+		{
+			name:     "Um centavo",
+			input:    "0.01",
+			expected: "um centavo",
+		},
+		{
+			name:     "Zero real",
+			input:    "0",
+			expected: "zero real",
+		},
+		{
+			name:     "Rounded to zero",
+			input:    "0.004",
+			expected: "zero real",
+		},
+		{
+			name:     "Rounded half away from zero",
+			input:    "0.125",
+			expected: "treze centavos",
+		},
+		{
+			name:     "Um milhao de reais",
+			input:    "1000000",
+			expected: "um milhão de reais",
+		},
+		{
+			name:     "Dois bilhoes de reais e dez centavos",
+			input:    "2000000000.10",
+			expected: "dois bilhões de reais e dez centavos",
+		},
+		{
+			name:     "No de after mil",
+			input:    "2000",
+			expected: "dois mil reais",
+		},
+		{
+			name:     "No de after lower classes",
+			input:    "1500000",
+			expected: "um milhão e quinhentos mil reais",
+		},
+		{
+			name:     "Mil e um reais",
+			input:    "1001",
+			expected: "mil e um reais",
+		},
+		{
+			name:     "Negative",
+			input:    "-2.5",
+			expected: "menos dois reais e cinquenta centavos",
+		},
+		{
+			name:     "Rounded negative",
+			input:    "-0.005",
+			expected: "menos um centavo",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input, _ := new(big.Rat).SetString(test.input)
+
+			result := NewSpeller().SpellCurrency(input)
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellCents(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    int64
+		expected string
+	}{
+		{
+			name:     "Reais e centavos",
+			input:    123456,
+			expected: "mil e duzentos e trinta e quatro reais e cinquenta e seis centavos",
+		},
+		{
+			name:     "Centavos alone",
+			input:    99,
+			expected: "noventa e nove centavos",
+		},
+		{
+			name:     "Um milhao de reais",
+			input:    100000000,
+			expected: "um milhão de reais",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := NewSpeller().SpellCents(big.NewInt(test.input))
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellCurrencyIgnoresGender(t *testing.T) {
+	speller := NewSpeller()
+	speller.SetGender(Feminine)
+
+	if result := speller.SpellCents(big.NewInt(201)); result != "dois reais e um centavo" {
+		t.Errorf("expected dois reais e um centavo, got %v", result)
+	}
+}
+
+func TestSpellerSetCurrency(t *testing.T) {
+	escudo := Currency{
+		Code:        "CVE",
+		Major:       CurrencyUnit{"escudo", "escudos", Masculine},
+		Minor:       CurrencyUnit{"centavo", "centavos", Masculine},
+		MinorDigits: 2,
+	}
+
+	tests := []struct {
+		name     string
+		currency Currency
+		input    string
+		expected string
+	}{
+		{
+			name:     "Duas libras",
+			currency: Currencies["GBP"],
+			input:    "2",
+			expected: "duas libras",
+		},
+		{
+			name:     "Duzentas e uma libras",
+			currency: Currencies["GBP"],
+			input:    "201",
+			expected: "duzentas e uma libras",
+		},
+		{
+			name:     "Uma libra e um peni",
+			currency: Currencies["GBP"],
+			input:    "1.01",
+			expected: "uma libra e um pêni",
+		},
+		{
+			name:     "Duas mil libras e dois pence",
+			currency: Currencies["GBP"],
+			input:    "2000.02",
+			expected: "duas mil libras e dois pence",
+		},
+		{
+			name:     "Um milhao de libras",
+			currency: Currencies["GBP"],
+			input:    "1000000",
+			expected: "um milhão de libras",
+		},
+		{
+			name:     "Dolares",
+			currency: Currencies["USD"],
+			input:    "1.5",
+			expected: "um dólar e cinquenta centavos",
+		},
+		{
+			name:     "Euros",
+			currency: Currencies["EUR"],
+			input:    "21.21",
+			expected: "vinte e um euros e vinte e um cêntimos",
+		},
+		{
+			name:     "No minor unit",
+			currency: Currencies["JPY"],
+			input:    "1234.6",
+			expected: "mil e duzentos e trinta e cinco ienes",
+		},
+		{
+			name:     "Three minor digits",
+			currency: Currencies["KWD"],
+			input:    "1.005",
+			expected: "um dinar e cinco fils",
+		},
+		{
+			name:     "Zero",
+			currency: Currencies["GBP"],
+			input:    "0",
+			expected: "zero libra",
+		},
+		{
+			name:     "Currency built by hand",
+			currency: escudo,
+			input:    "3.1",
+			expected: "três escudos e dez centavos",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input, _ := new(big.Rat).SetString(test.input)
+
+			speller := NewSpeller()
+			speller.SetCurrency(test.currency)
+
+			result := speller.SpellCurrency(input)
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Money
+		expected string
+	}{
+		{
+			name:     "Reais",
+			input:    Money{Amount: big.NewRat(63, 2), Currency: "BRL"},
+			expected: "trinta e um reais e cinquenta centavos",
+		},
+		{
+			name:     "Libras",
+			input:    Money{Amount: big.NewRat(201, 1), Currency: "GBP"},
+			expected: "duzentas e uma libras",
+		},
+		{
+			name:     "Unregistered currency",
+			input:    Money{Amount: big.NewRat(2, 1), Currency: "XXX"},
+			expected: "dois reais",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := NewSpeller().SpellMoney(test.input)
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		input    Money
+		expected string
+	}{
+		{input: Money{Amount: big.NewRat(63, 2), Currency: "BRL"}, expected: "31.50 BRL"},
+		{input: Money{Amount: big.NewRat(1, 3), Currency: "KWD"}, expected: "0.333 KWD"},
+		{input: Money{Amount: big.NewRat(1234, 1), Currency: "JPY"}, expected: "1234 JPY"},
+		{input: Money{Amount: big.NewRat(5, 2), Currency: "XXX"}, expected: "2.5 XXX"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			if result := test.input.String(); result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package spellnumber
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// RenderDiagnostic prints line, the original input the token was read from,
+// with a caret under the start of the token and a tilde under each of its
+// remaining characters, preceded by the token location and message:
+//
+//	linha 1, coluna 11: Lexema 'xyz' não reconhecido
+//	dois mais xyz
+//	          ^~~
+func RenderDiagnostic(line string, token Token) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("linha %d, coluna %d", token.Pos.Line, token.Pos.Column))
+
+	if token.Spell != "" {
+		builder.WriteString(": ")
+		builder.WriteString(token.Spell)
+	}
+
+	builder.WriteString("\n")
+	builder.WriteString(line)
+	builder.WriteString("\n")
+
+	start := min(max(token.Pos.Offset, 0), len(line))
+	end := min(max(token.End.Offset, start), len(line))
+
+	// Keep tabs so the caret lines up with terminals that expand them
+	for _, r := range line[:start] {
+		if r == '\t' {
+			builder.WriteRune('\t')
+			continue
+		}
+
+		builder.WriteRune(' ')
+	}
+
+	builder.WriteRune('^')
+
+	for i := 1; i < utf8.RuneCountInString(line[start:end]); i++ {
+		builder.WriteRune('~')
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,55 @@
+package spellnumber
+
+import "testing"
+
+func TestRenderDiagnostic(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Unknown word",
+			input:    "dois mais xyz",
+			expected: "linha 1, coluna 11: Lexema 'xyz' não reconhecido\ndois mais xyz\n          ^~~",
+		},
+		{
+			name:     "Accented words before the error",
+			input:    "três  vezes\tabc",
+			expected: "linha 1, coluna 13: Lexema 'abc' não reconhecido\ntrês  vezes\tabc\n           \t^~~",
+		},
+		{
+			name:     "Missing word at end of line",
+			input:    "dois elevado",
+			expected: "linha 1, coluna 13: Esperado 'por', 'a' ou 'ao' após 'elevado'\ndois elevado\n            ^",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lexer := NewLexer(nil)
+			tokens, err := lexer.ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var errorToken *Token
+
+			for i := range tokens {
+				if tokens[i].Type == TOKEN_ERROR {
+					errorToken = &tokens[i]
+					break
+				}
+			}
+
+			if errorToken == nil {
+				t.Fatalf("expected an error token, got %v", tokens)
+			}
+
+			if result := RenderDiagnostic(test.input, *errorToken); result != test.expected {
+				t.Errorf("expected\n%s\ngot\n%s", test.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package spellnumber
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// diagramEdge merges the transitions between two states taken with the same
+// action into a single edge, labelled with their classes.
+type diagramEdge struct {
+	from    string
+	to      string
+	action  string
+	classes []string
+}
+
+// diagramEdges returns the edges of the lexer automaton. Errors are left out:
+// every word without an edge is rejected.
+func diagramEdges() []diagramEdge {
+	edges := make([]diagramEdge, 0, 64)
+
+	for _, t := range LexerTransitions() {
+		if t.Action == "error" {
+			continue
+		}
+
+		i := slices.IndexFunc(edges, func(e diagramEdge) bool {
+			return e.from == t.From && e.to == t.To && e.action == t.Action
+		})
+
+		if i < 0 {
+			edges = append(edges, diagramEdge{from: t.From, to: t.To, action: t.Action})
+			i = len(edges) - 1
+		}
+
+		edges[i].classes = append(edges[i].classes, t.Class)
+	}
+
+	return edges
+}
+
+// vocabulary returns the words of the lexer dictionary by class.
+func (l *Lexer) vocabulary() map[string][]string {
+	words := make(map[string][]string)
+
+	for word, val := range l.numberDict {
+		if _, ok := l.localDict[word]; ok {
+			continue
+		}
+
+		name := wordClassNames[val.class]
+		words[name] = append(words[name], word)
+	}
+
+	for word, val := range l.localDict {
+		name := wordClassNames[val.class]
+		words[name] = append(words[name], word)
+	}
+
+	for _, phrase := range operatorPhrases {
+		name := wordClassNames[classOperator]
+		words[name] = append(words[name], strings.Join(phrase.words, " "))
+	}
+
+	if l.symbols {
+		name := wordClassNames[classOperator]
+		words[name] = append(words[name], "x")
+
+		for symbol := range symbolOperators {
+			words[name] = append(words[name], symbol)
+		}
+	}
+
+	for _, list := range words {
+		slices.Sort(list)
+	}
+
+	return words
+}
+
+func stateID(name string) string {
+	return strings.Fields(name)[0]
+}
+
+func stateNames() []string {
+	names := make([]string, 0, lexStateCount)
+
+	for _, row := range lexTable {
+		names = append(names, row.name)
+	}
+
+	return names
+}
+
+func vocabularyClasses() []string {
+	classes := make([]string, 0, wordClassCount)
+
+	for c := classOperator; c < wordClassCount; c++ {
+		classes = append(classes, wordClassNames[c])
+	}
+
+	return classes
+}
+
+// WriteDOT writes the lexer automaton as a Graphviz DOT graph, along with the
+// words of each class. Edges that finish a number are dashed; words without
+// an edge are rejected.
+func (l *Lexer) WriteDOT(w io.Writer) error {
+	b := strings.Builder{}
+
+	b.WriteString("digraph lexer {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=circle];\n")
+
+	for _, name := range stateNames() {
+		fmt.Fprintf(&b, "\t%s [label=%q];\n", stateID(name), name)
+	}
+
+	for _, e := range diagramEdges() {
+		style := ""
+
+		if e.action == "finish" {
+			style = ", style=dashed"
+		}
+
+		fmt.Fprintf(&b, "\t%s -> %s [label=%q%s];\n", stateID(e.from), stateID(e.to), strings.Join(e.classes, ", "), style)
+	}
+
+	vocabulary := l.vocabulary()
+
+	b.WriteString("\tvocabulary [shape=note, label=\"")
+
+	for _, class := range vocabularyClasses() {
+		fmt.Fprintf(&b, "%s: %s\\l", class, strings.Join(vocabulary[class], ", "))
+	}
+
+	b.WriteString("\"];\n")
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// WriteMermaid writes the lexer automaton as a Mermaid flowchart, along with
+// the words of each class, ready to be embedded in Markdown.
+func (l *Lexer) WriteMermaid(w io.Writer) error {
+	b := strings.Builder{}
+
+	b.WriteString("flowchart LR\n")
+
+	for _, name := range stateNames() {
+		fmt.Fprintf(&b, "\t%s((\"%s\"))\n", stateID(name), name)
+	}
+
+	for _, e := range diagramEdges() {
+		arrow := "-->"
+
+		if e.action == "finish" {
+			arrow = "-.->"
+		}
+
+		fmt.Fprintf(&b, "\t%s %s|\"%s\"| %s\n", stateID(e.from), arrow, strings.Join(e.classes, ", "), stateID(e.to))
+	}
+
+	vocabulary := l.vocabulary()
+
+	b.WriteString("\tvocabulary[\"")
+
+	for i, class := range vocabularyClasses() {
+		if i > 0 {
+			b.WriteString("<br/>")
+		}
+
+		fmt.Fprintf(&b, "%s: %s", class, strings.Join(vocabulary[class], ", "))
+	}
+
+	b.WriteString("\"]\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
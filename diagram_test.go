@@ -0,0 +1,56 @@
+package spellnumber
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLexerWriteDOT(t *testing.T) {
+	builder := strings.Builder{}
+
+	if err := NewLexer(nil).WriteDOT(&builder); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dot := builder.String()
+
+	expected := []string{
+		"digraph lexer {",
+		"\tq5 [label=\"q5 cento\"];",
+		"\tq5 -> q7 [label=\"e\"];",
+		"\tq2 -> q0 [label=\"{outra}, {operador}\", style=dashed];",
+		"quadrilhao",
+		"septilhao",
+		"setilhao",
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(dot, line) {
+			t.Errorf("expected %q in\n%s", line, dot)
+		}
+	}
+}
+
+func TestLexerWriteMermaid(t *testing.T) {
+	builder := strings.Builder{}
+
+	if err := NewLexer(nil).WriteMermaid(&builder); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mermaid := builder.String()
+
+	expected := []string{
+		"flowchart LR",
+		"\tq0((\"q0\"))",
+		"\tq0 -->|\"{unidade}\"| q2",
+		"\tq9 -.->|\"{outra}, {operador}\"| q0",
+		"elevado ao quadrado",
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(mermaid, line) {
+			t.Errorf("expected %q in\n%s", line, mermaid)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package spellnumber
+
+import "fmt"
+
+// ErrorCode identifies the kind of a lexer or parser failure. It is an error
+// itself, so errors.Is(err, ErrUnknownLexeme) matches any LexError or
+// ParseError carrying that code.
+type ErrorCode string
+
+const (
+	// Lexer failures
+	ErrUnknownLexeme    ErrorCode = "unknown lexeme"
+	ErrExpectedWord     ErrorCode = "expected word"
+	ErrUnexpectedNumber ErrorCode = "unexpected number"
+	ErrInvalidNumber    ErrorCode = "invalid number"
+
+	// Shared by the lexer and the parser
+	ErrExpectedNumber ErrorCode = "expected number"
+
+	// Parser failures
+	ErrUnclosedParenthesis ErrorCode = "unclosed parenthesis"
+	ErrExpectedOperator    ErrorCode = "expected operator"
+	ErrUnexpectedToken     ErrorCode = "unexpected token"
+	ErrDivisionByZero      ErrorCode = "division by zero"
+	ErrNotInteger          ErrorCode = "not an integer"
+	ErrNoCurrency          ErrorCode = "no currency"
+	ErrMixedCurrencies     ErrorCode = "mixed currencies"
+)
+
+func (c ErrorCode) Error() string {
+	return string(c)
+}
+
+// LexError is the failure behind a TOKEN_ERROR token.
+type LexError struct {
+	Code ErrorCode
+	Pos  Position
+	End  Position
+	// Lexeme is the offending word, after normalisation
+	Lexeme string
+	// Expected lists the words or word classes ({unidade}, {dezena},
+	// {centena}, {milhar}) that would have been accepted instead
+	Expected []string
+	Message  string
+}
+
+func (e *LexError) Error() string {
+	return errorMessage(e.Pos, e.Message)
+}
+
+func (e *LexError) Unwrap() error {
+	return e.Code
+}
+
+// ParseError is a failure found by the parser while reading the tokens.
+type ParseError struct {
+	Code ErrorCode
+	Pos  Position
+	End  Position
+	// Lexeme is the value of the offending token, empty at the end of input
+	Lexeme   string
+	Expected []string
+	Message  string
+}
+
+func (e *ParseError) Error() string {
+	return errorMessage(e.Pos, e.Message)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Code
+}
+
+// errorMessage prefixes message with pos, when the failure has one.
+func errorMessage(pos Position, message string) string {
+	if pos.Line == 0 {
+		return message
+	}
+
+	return fmt.Sprintf("linha %d, coluna %d: %s", pos.Line, pos.Column, message)
+}
@@ -0,0 +1,80 @@
+package spellnumber
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		code     ErrorCode
+		lexeme   string
+		expected []string
+		pos      Position
+	}{
+		{
+			name:   "Unknown word",
+			input:  "dois mais xyz",
+			code:   ErrUnknownLexeme,
+			lexeme: "xyz",
+			pos:    Position{Offset: 10, Line: 1, Column: 11, Word: 2},
+		},
+		{
+			name:     "Missing 'por' after 'dividido'",
+			input:    "dez dividido dois",
+			code:     ErrExpectedWord,
+			lexeme:   "dois",
+			expected: []string{"por"},
+			pos:      Position{Offset: 13, Line: 1, Column: 14, Word: 2},
+		},
+		{
+			name:     "Unclosed parenthesis",
+			input:    "abre parentese dois mais um",
+			code:     ErrUnclosedParenthesis,
+			expected: []string{"fecha parentese"},
+			pos:      Position{Offset: 27, Line: 1, Column: 28, Word: 4},
+		},
+		{
+			name:     "Missing number",
+			input:    "dois mais",
+			code:     ErrExpectedNumber,
+			expected: []string{"{número}"},
+			pos:      Position{Offset: 9, Line: 1, Column: 10, Word: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens, err := NewLexer(nil).ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, err = NewParser(tokens).Parse()
+
+			if !errors.Is(err, test.code) {
+				t.Fatalf("expected error code %q, got %v", test.code, err)
+			}
+
+			var lexErr *LexError
+			var parseErr *ParseError
+
+			switch {
+			case errors.As(err, &lexErr):
+				if lexErr.Lexeme != test.lexeme || lexErr.Pos != test.pos || !slices.Equal(lexErr.Expected, test.expected) {
+					t.Errorf("unexpected lexer error %+v", lexErr)
+				}
+			case errors.As(err, &parseErr):
+				if parseErr.Lexeme != test.lexeme || parseErr.Pos != test.pos || !slices.Equal(parseErr.Expected, test.expected) {
+					t.Errorf("unexpected parser error %+v", parseErr)
+				}
+			default:
+				t.Errorf("expected a typed error, got %T", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,623 @@
+package spellnumber
+
+// lexState is a state of the lexer automaton. Besides q0, where tokens start,
+// and q1, inside an operator phrase, every state is named after the last
+// class of number word read. The decimal part after "virgula" is read by the
+// same states as the integer part, the denominator read before "avos" by the
+// states named after "/". Ordinals have states of their own. Money amounts are
+// read by the number states up to the name of the currency, and the amount in
+// its minor unit after "e" by the same states again.
+type lexState int
+
+const (
+	stateStart lexState = iota
+	statePhrase
+	stateUnit
+	stateTen
+	stateHundredExact
+	stateCento
+	stateHundred
+	stateHundredAnd
+	stateTenAnd
+	stateScale
+	stateScaleAnd
+	stateZero
+	stateUnitAnd
+	stateComma
+	stateCommaZero
+	stateHalf
+	stateFraction
+	stateOverUnit
+	stateOverTen
+	stateOverTenAnd
+	stateOverHundred
+	stateOverCento
+	stateOverHundredAnd
+	stateOrdinalHundred
+	stateOrdinalTen
+	stateOrdinalUnit
+	stateOrdinalScale
+	stateMajor
+	stateMajorAnd
+	stateMinor
+	stateScaleOf
+
+	lexStateCount
+)
+
+// wordClass groups the words that the automaton handles the same way.
+type wordClass int
+
+const (
+	classOther wordClass = iota
+	classOperator
+	classUnit
+	classTen
+	classHundredExact
+	classCento
+	classHundred
+	classThousand
+	classScale
+	classZero
+	classAnd
+	classComma
+	classHalf
+	classFraction
+	classAvos
+	classOrdinalFirst
+	classOrdinalUnit
+	classOrdinalTen
+	classOrdinalHundred
+	classOrdinalScale
+	classMajor
+	classMinor
+	classOf
+
+	wordClassCount
+)
+
+// lexAction is what the engine does with the word read by a transition.
+type lexAction int
+
+const (
+	// actionPush appends the word to the number being read
+	actionPush lexAction = iota
+	// actionSkip consumes a word linking the classes of a number ("e")
+	actionSkip
+	// actionFinish closes the number before the word, which is read again
+	// from q0
+	actionFinish
+	// actionOperator reads the word as part of an operator phrase or symbol
+	actionOperator
+	// actionError rejects the word
+	actionError
+)
+
+type lexTransition struct {
+	action lexAction
+	next   lexState
+	// split makes the word pushed start the denominator of a fraction
+	split bool
+	// lookahead, when set, only lets the transition be taken if the number
+	// words that follow are closed by a word of one of those classes ("avos").
+	// Otherwise the transition for other number words applies.
+	lookahead []wordClass
+	// code, message and expected describe the failure of actionError. The
+	// message may refer to the word with %s.
+	code     ErrorCode
+	message  string
+	expected []string
+}
+
+type lexStateRow struct {
+	name string
+	on   map[wordClass]lexTransition
+	// otherNumber applies to the number words missing from on and otherWord
+	// to every other word, the end of the line included
+	otherNumber lexTransition
+	otherWord   lexTransition
+}
+
+var (
+	finish      = lexTransition{action: actionFinish, next: stateStart}
+	operator    = lexTransition{action: actionOperator, next: statePhrase}
+	unknownWord = lexTransition{action: actionError, code: ErrUnknownLexeme, message: "Lexema '%s' não reconhecido"}
+)
+
+func push(next lexState) lexTransition {
+	return lexTransition{action: actionPush, next: next}
+}
+
+// over pushes the first word of the denominator of a fraction: a fraction
+// word such as "terco" or, when "avos" closes it, a number.
+func over(next lexState) lexTransition {
+	if next == stateFraction {
+		return lexTransition{action: actionPush, next: next, split: true}
+	}
+
+	return lexTransition{action: actionPush, next: next, split: true, lookahead: []wordClass{classAvos}}
+}
+
+func skip(next lexState) lexTransition {
+	return lexTransition{action: actionSkip, next: next}
+}
+
+// of pushes "de" when the name of the currency or a scale name follows it, as
+// in "um milhao de reais" or "um decilhao de decilhoes", where the scale
+// multiplies the whole number read before "de".
+func of(next lexState) lexTransition {
+	return lexTransition{action: actionPush, next: next, lookahead: []wordClass{classMajor, classScale}}
+}
+
+// ofScale pushes "de" when a scale name follows it, in the multiples of the
+// largest scale name, as in "dois milhoes e cinco de
+// novennonagintanongentilhoes".
+func ofScale(next lexState) lexTransition {
+	return lexTransition{action: actionPush, next: next, lookahead: []wordClass{classScale}}
+}
+
+func reject(code ErrorCode, message string, expected ...string) lexTransition {
+	return lexTransition{action: actionError, code: code, message: message, expected: expected}
+}
+
+// lexTable is the lexer automaton: for each state, the transition taken on
+// each class of word. Vocabulary changes belong to the lexer dictionaries and
+// grammar changes to this table; the engine in parseLine only interprets it.
+var lexTable = [lexStateCount]lexStateRow{
+	stateStart: {
+		name: "q0",
+		on: map[wordClass]lexTransition{
+			classOperator:       operator,
+			classUnit:           push(stateUnit),
+			classTen:            push(stateTen),
+			classHundredExact:   push(stateHundredExact),
+			classCento:          push(stateCento),
+			classHundred:        push(stateHundred),
+			classThousand:       push(stateScale),
+			classZero:           push(stateZero),
+			classOrdinalFirst:   push(stateOrdinalUnit),
+			classOrdinalUnit:    push(stateOrdinalUnit),
+			classOrdinalTen:     push(stateOrdinalTen),
+			classOrdinalHundred: push(stateOrdinalHundred),
+			classOrdinalScale:   push(stateOrdinalScale),
+		},
+		otherNumber: unknownWord,
+		otherWord:   unknownWord,
+	},
+	statePhrase: {
+		name:        "q1 {operador}",
+		otherNumber: operator,
+		otherWord:   operator,
+	},
+	stateUnit: {
+		name: "q2 {unidade}",
+		on: map[wordClass]lexTransition{
+			classAnd:            skip(stateUnitAnd),
+			classThousand:       push(stateScale),
+			classScale:          push(stateScale),
+			classOf:             ofScale(stateScaleOf),
+			classComma:          push(stateComma),
+			classFraction:       over(stateFraction),
+			classOrdinalUnit:    over(stateFraction),
+			classOrdinalTen:     over(stateFraction),
+			classOrdinalHundred: over(stateFraction),
+			classOrdinalScale:   over(stateFraction),
+			classUnit:           over(stateOverUnit),
+			classTen:            over(stateOverTen),
+			classHundredExact:   over(stateOverHundred),
+			classCento:          over(stateOverCento),
+			classHundred:        over(stateOverHundred),
+			classMajor:          push(stateMajor),
+			classMinor:          push(stateMinor),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Não é esperado um número após '{unidade}'", "{milhar}", "virgula"),
+		otherWord:   finish,
+	},
+	stateTen: {
+		name: "q3 {dezena}",
+		on: map[wordClass]lexTransition{
+			classAnd:            skip(stateTenAnd),
+			classThousand:       push(stateScale),
+			classScale:          push(stateScale),
+			classOf:             ofScale(stateScaleOf),
+			classComma:          push(stateComma),
+			classFraction:       over(stateFraction),
+			classOrdinalUnit:    over(stateFraction),
+			classOrdinalTen:     over(stateFraction),
+			classOrdinalHundred: over(stateFraction),
+			classOrdinalScale:   over(stateFraction),
+			classUnit:           over(stateOverUnit),
+			classTen:            over(stateOverTen),
+			classHundredExact:   over(stateOverHundred),
+			classCento:          over(stateOverCento),
+			classHundred:        over(stateOverHundred),
+			classMajor:          push(stateMajor),
+			classMinor:          push(stateMinor),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Não é esperado um número após '{dezena}'", "e", "{milhar}", "virgula"),
+		otherWord:   finish,
+	},
+	stateHundredExact: {
+		name: "q4 cem",
+		on: map[wordClass]lexTransition{
+			classAnd:            skip(stateUnitAnd),
+			classThousand:       push(stateScale),
+			classScale:          push(stateScale),
+			classOf:             ofScale(stateScaleOf),
+			classComma:          push(stateComma),
+			classFraction:       over(stateFraction),
+			classOrdinalUnit:    over(stateFraction),
+			classOrdinalTen:     over(stateFraction),
+			classOrdinalHundred: over(stateFraction),
+			classOrdinalScale:   over(stateFraction),
+			classUnit:           over(stateOverUnit),
+			classTen:            over(stateOverTen),
+			classHundredExact:   over(stateOverHundred),
+			classCento:          over(stateOverCento),
+			classHundred:        over(stateOverHundred),
+			classMajor:          push(stateMajor),
+			classMinor:          push(stateMinor),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Não é esperado U/D/C após 'cem'", "{milhar}", "virgula"),
+		otherWord:   finish,
+	},
+	stateCento: {
+		name: "q5 cento",
+		on: map[wordClass]lexTransition{
+			classAnd: skip(stateHundredAnd),
+		},
+		otherNumber: reject(ErrExpectedWord, "Esperado 'e' após 'cento'", "e"),
+		otherWord:   reject(ErrExpectedWord, "Esperado 'e' após 'cento'", "e"),
+	},
+	stateHundred: {
+		name: "q6 {centena}",
+		on: map[wordClass]lexTransition{
+			classAnd:            skip(stateHundredAnd),
+			classThousand:       push(stateScale),
+			classScale:          push(stateScale),
+			classOf:             ofScale(stateScaleOf),
+			classComma:          push(stateComma),
+			classFraction:       over(stateFraction),
+			classOrdinalUnit:    over(stateFraction),
+			classOrdinalTen:     over(stateFraction),
+			classOrdinalHundred: over(stateFraction),
+			classOrdinalScale:   over(stateFraction),
+			classUnit:           over(stateOverUnit),
+			classTen:            over(stateOverTen),
+			classHundredExact:   over(stateOverHundred),
+			classCento:          over(stateOverCento),
+			classHundred:        over(stateOverHundred),
+			classMajor:          push(stateMajor),
+			classMinor:          push(stateMinor),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Esperado 'e' ou milhar após '{centena}'", "e", "{milhar}", "virgula"),
+		otherWord:   finish,
+	},
+	stateHundredAnd: {
+		name: "q7 {centena} e",
+		on: map[wordClass]lexTransition{
+			classUnit: push(stateUnit),
+			classTen:  push(stateTen),
+			classHalf: push(stateHalf),
+		},
+		otherNumber: reject(ErrExpectedNumber, "Esperado dezena, unidade ou 'meio' após '{centena} e'", "{dezena}", "{unidade}", "meio"),
+		otherWord:   reject(ErrExpectedNumber, "Esperado dezena, unidade ou 'meio' após '{centena} e'", "{dezena}", "{unidade}", "meio"),
+	},
+	stateTenAnd: {
+		name: "q8 {dezena} e",
+		on: map[wordClass]lexTransition{
+			classUnit: push(stateUnit),
+			classHalf: push(stateHalf),
+		},
+		otherNumber: reject(ErrExpectedNumber, "Esperado unidade ou 'meio' após '{dezena} e'", "{unidade}", "meio"),
+		otherWord:   reject(ErrExpectedNumber, "Esperado unidade ou 'meio' após '{dezena} e'", "{unidade}", "meio"),
+	},
+	stateScale: {
+		name: "q9 {milhar}",
+		on: map[wordClass]lexTransition{
+			classAnd:          skip(stateScaleAnd),
+			classUnit:         push(stateUnit),
+			classTen:          push(stateTen),
+			classHundredExact: push(stateHundredExact),
+			classCento:        push(stateCento),
+			classHundred:      push(stateHundred),
+			classThousand:     push(stateScale),
+			classScale:        push(stateScale),
+			classComma:        push(stateComma),
+			classFraction:     over(stateFraction),
+			classMajor:        push(stateMajor),
+			classMinor:        push(stateMinor),
+			classOf:           of(stateScaleOf),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Esperado 'e' ou U/C/D depois de '{milhar}'", "e", "{unidade}", "{dezena}", "{centena}", "virgula"),
+		otherWord:   finish,
+	},
+	stateScaleAnd: {
+		name: "q10 {milhar} e",
+		on: map[wordClass]lexTransition{
+			classUnit:         push(stateUnit),
+			classTen:          push(stateTen),
+			classHundredExact: push(stateHundredExact),
+			classCento:        push(stateCento),
+			classHundred:      push(stateHundred),
+			classThousand:     push(stateScale),
+			classHalf:         push(stateHalf),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Esperado U/C/D ou 'meio' depois de '{milhar} e'", "{unidade}", "{dezena}", "{centena}", "meio"),
+		otherWord:   finish,
+	},
+	stateZero: {
+		name: "q11 zero",
+		on: map[wordClass]lexTransition{
+			classComma: push(stateComma),
+			classMajor: push(stateMajor),
+			classMinor: push(stateMinor),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Não esperado número após 'zero'", "virgula"),
+		otherWord:   finish,
+	},
+	stateUnitAnd: {
+		name: "q12 {unidade} e",
+		on: map[wordClass]lexTransition{
+			classHalf: push(stateHalf),
+		},
+		otherNumber: reject(ErrExpectedWord, "Esperado 'meio' após '{unidade} e'", "meio"),
+		otherWord:   reject(ErrExpectedWord, "Esperado 'meio' após '{unidade} e'", "meio"),
+	},
+	// The decimal part is read as an integer, after the zeros opening it
+	stateComma: {
+		name: "q13 virgula",
+		on: map[wordClass]lexTransition{
+			classUnit:         push(stateUnit),
+			classTen:          push(stateTen),
+			classHundredExact: push(stateHundredExact),
+			classCento:        push(stateCento),
+			classHundred:      push(stateHundred),
+			classZero:         push(stateCommaZero),
+		},
+		otherNumber: reject(ErrExpectedNumber, "Esperado um número após 'virgula'", "{unidade}", "{dezena}", "{centena}", "zero"),
+		otherWord:   reject(ErrExpectedNumber, "Esperado um número após 'virgula'", "{unidade}", "{dezena}", "{centena}", "zero"),
+	},
+	stateCommaZero: {
+		name: "q14 virgula zero",
+		on: map[wordClass]lexTransition{
+			classUnit:         push(stateUnit),
+			classTen:          push(stateTen),
+			classHundredExact: push(stateHundredExact),
+			classCento:        push(stateCento),
+			classHundred:      push(stateHundred),
+			classZero:         push(stateCommaZero),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Esperado U/D/C ou 'zero' após 'virgula zero'", "{unidade}", "{dezena}", "{centena}", "zero"),
+		otherWord:   finish,
+	},
+	stateHalf: {
+		name: "q15 meio",
+		on: map[wordClass]lexTransition{
+			classOf: of(stateScaleOf),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Não é esperado um número após 'meio'"),
+		otherWord:   finish,
+	},
+	stateFraction: {
+		name:        "q16 {fração}",
+		otherNumber: reject(ErrUnexpectedNumber, "Não é esperado um número após a fração"),
+		otherWord:   finish,
+	},
+	stateOverUnit: {
+		name: "q17 / {unidade}",
+		on: map[wordClass]lexTransition{
+			classAvos: push(stateFraction),
+		},
+		otherNumber: reject(ErrExpectedWord, "Esperado 'avos' após o denominador", "avos"),
+		otherWord:   reject(ErrExpectedWord, "Esperado 'avos' após o denominador", "avos"),
+	},
+	stateOverTen: {
+		name: "q18 / {dezena}",
+		on: map[wordClass]lexTransition{
+			classAnd:  skip(stateOverTenAnd),
+			classAvos: push(stateFraction),
+		},
+		otherNumber: reject(ErrExpectedWord, "Esperado 'e' ou 'avos' após o denominador", "e", "avos"),
+		otherWord:   reject(ErrExpectedWord, "Esperado 'e' ou 'avos' após o denominador", "e", "avos"),
+	},
+	stateOverTenAnd: {
+		name: "q19 / {dezena} e",
+		on: map[wordClass]lexTransition{
+			classUnit: push(stateOverUnit),
+		},
+		otherNumber: reject(ErrExpectedNumber, "Esperado unidade após '{dezena} e'", "{unidade}"),
+		otherWord:   reject(ErrExpectedNumber, "Esperado unidade após '{dezena} e'", "{unidade}"),
+	},
+	stateOverHundred: {
+		name: "q20 / {centena}",
+		on: map[wordClass]lexTransition{
+			classAnd:  skip(stateOverHundredAnd),
+			classAvos: push(stateFraction),
+		},
+		otherNumber: reject(ErrExpectedWord, "Esperado 'e' ou 'avos' após o denominador", "e", "avos"),
+		otherWord:   reject(ErrExpectedWord, "Esperado 'e' ou 'avos' após o denominador", "e", "avos"),
+	},
+	stateOverCento: {
+		name: "q21 / cento",
+		on: map[wordClass]lexTransition{
+			classAnd: skip(stateOverHundredAnd),
+		},
+		otherNumber: reject(ErrExpectedWord, "Esperado 'e' após 'cento'", "e"),
+		otherWord:   reject(ErrExpectedWord, "Esperado 'e' após 'cento'", "e"),
+	},
+	stateOverHundredAnd: {
+		name: "q22 / {centena} e",
+		on: map[wordClass]lexTransition{
+			classUnit: push(stateOverUnit),
+			classTen:  push(stateOverTen),
+		},
+		otherNumber: reject(ErrExpectedNumber, "Esperado dezena ou unidade após '{centena} e'", "{dezena}", "{unidade}"),
+		otherWord:   reject(ErrExpectedNumber, "Esperado dezena ou unidade após '{centena} e'", "{dezena}", "{unidade}"),
+	},
+	stateOrdinalHundred: {
+		name: "q23 {centésimo}",
+		on: map[wordClass]lexTransition{
+			classOrdinalFirst: push(stateOrdinalUnit),
+			classOrdinalUnit:  push(stateOrdinalUnit),
+			classOrdinalTen:   push(stateOrdinalTen),
+			classOrdinalScale: push(stateOrdinalScale),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Esperado ordinal de dezena, unidade ou milhar após '{centésimo}'", "{décimo}", "{primeiro}", "{milésimo}"),
+		otherWord:   finish,
+	},
+	stateOrdinalTen: {
+		name: "q24 {décimo}",
+		on: map[wordClass]lexTransition{
+			classOrdinalFirst: push(stateOrdinalUnit),
+			classOrdinalUnit:  push(stateOrdinalUnit),
+			classOrdinalScale: push(stateOrdinalScale),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Esperado ordinal de unidade ou milhar após '{décimo}'", "{primeiro}", "{milésimo}"),
+		otherWord:   finish,
+	},
+	stateOrdinalUnit: {
+		name: "q25 {primeiro}",
+		on: map[wordClass]lexTransition{
+			classOrdinalScale: push(stateOrdinalScale),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Esperado ordinal de milhar após '{primeiro}'", "{milésimo}"),
+		otherWord:   finish,
+	},
+	stateOrdinalScale: {
+		name: "q26 {milésimo}",
+		on: map[wordClass]lexTransition{
+			classOrdinalFirst:   push(stateOrdinalUnit),
+			classOrdinalUnit:    push(stateOrdinalUnit),
+			classOrdinalTen:     push(stateOrdinalTen),
+			classOrdinalHundred: push(stateOrdinalHundred),
+			// "milionesimo milesimo", sumClasses checks the order of the scales
+			classOrdinalScale: push(stateOrdinalScale),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Esperado ordinal de centena, dezena, unidade ou milhar após '{milésimo}'", "{centésimo}", "{décimo}", "{primeiro}", "{milésimo}"),
+		otherWord:   finish,
+	},
+	stateMajor: {
+		name: "q27 {moeda}",
+		on: map[wordClass]lexTransition{
+			classAnd: skip(stateMajorAnd),
+		},
+		otherNumber: reject(ErrUnexpectedNumber, "Esperado 'e' após '{moeda}'", "e"),
+		otherWord:   finish,
+	},
+	// The amount in the minor unit is read by the number states, "centavos"
+	// closing it or not
+	stateMajorAnd: {
+		name: "q28 {moeda} e",
+		on: map[wordClass]lexTransition{
+			classUnit:         push(stateUnit),
+			classTen:          push(stateTen),
+			classHundredExact: push(stateHundredExact),
+			classCento:        push(stateCento),
+			classHundred:      push(stateHundred),
+		},
+		otherNumber: reject(ErrExpectedNumber, "Esperado U/D/C após '{moeda} e'", "{unidade}", "{dezena}", "{centena}"),
+		otherWord:   reject(ErrExpectedNumber, "Esperado U/D/C após '{moeda} e'", "{unidade}", "{dezena}", "{centena}"),
+	},
+	stateMinor: {
+		name:        "q29 {centavo}",
+		otherNumber: reject(ErrUnexpectedNumber, "Não é esperado um número após '{centavo}'"),
+		otherWord:   finish,
+	},
+	stateScaleOf: {
+		name: "q30 {milhar} de",
+		on: map[wordClass]lexTransition{
+			classMajor: push(stateMajor),
+			classScale: push(stateScale),
+		},
+		otherNumber: reject(ErrExpectedWord, "Esperado '{moeda}' ou '{milhar}' após 'de'", "{moeda}", "{milhar}"),
+		otherWord:   reject(ErrExpectedWord, "Esperado '{moeda}' ou '{milhar}' após 'de'", "{moeda}", "{milhar}"),
+	},
+}
+
+var wordClassNames = [wordClassCount]string{
+	classOther:          "{outra}",
+	classOperator:       "{operador}",
+	classUnit:           "{unidade}",
+	classTen:            "{dezena}",
+	classHundredExact:   "cem",
+	classCento:          "cento",
+	classHundred:        "{centena}",
+	classThousand:       "mil",
+	classScale:          "{milhar}",
+	classZero:           "zero",
+	classAnd:            "e",
+	classComma:          "virgula",
+	classHalf:           "meio",
+	classFraction:       "{fração}",
+	classAvos:           "avos",
+	classOrdinalFirst:   "{primeiro}",
+	classOrdinalUnit:    "{quarto}",
+	classOrdinalTen:     "{décimo}",
+	classOrdinalHundred: "{centésimo}",
+	classOrdinalScale:   "{milésimo}",
+	classMajor:          "{moeda}",
+	classMinor:          "{centavo}",
+	classOf:             "de",
+}
+
+var lexActionNames = map[lexAction]string{
+	actionPush:     "push",
+	actionSkip:     "skip",
+	actionFinish:   "finish",
+	actionOperator: "operator",
+	actionError:    "error",
+}
+
+func (c wordClass) isNumber() bool {
+	return c != classOther && c != classOperator
+}
+
+// transition returns the transition taken from state s on a word of class c.
+func (s lexState) transition(c wordClass) lexTransition {
+	row := lexTable[s]
+
+	if t, ok := row.on[c]; ok {
+		return t
+	}
+
+	if c.isNumber() {
+		return row.otherNumber
+	}
+
+	return row.otherWord
+}
+
+// LexerTransition is an edge of the lexer automaton, as listed by
+// LexerTransitions.
+type LexerTransition struct {
+	From string
+	// Class is the class of word read: a word such as "cem" or "e", or a
+	// class such as "{unidade}", "{milhar}" or "{operador}"
+	Class string
+	// Action is one of "push", "skip", "finish", "operator" or "error"
+	Action string
+	// To is the state reached, q0 after "finish" and "error"
+	To string
+}
+
+// LexerTransitions lists every transition of the lexer automaton, state by
+// state and class by class.
+func LexerTransitions() []LexerTransition {
+	transitions := make([]LexerTransition, 0, int(lexStateCount)*int(wordClassCount))
+
+	for s := stateStart; s < lexStateCount; s++ {
+		for c := classOther; c < wordClassCount; c++ {
+			t := s.transition(c)
+
+			transitions = append(transitions, LexerTransition{
+				From:   lexTable[s].name,
+				Class:  wordClassNames[c],
+				Action: lexActionNames[t.action],
+				To:     lexTable[t.next].name,
+			})
+		}
+	}
+
+	return transitions
+}
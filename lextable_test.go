@@ -0,0 +1,46 @@
+package spellnumber
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLexerTransitions(t *testing.T) {
+	transitions := LexerTransitions()
+
+	if len(transitions) != int(lexStateCount)*int(wordClassCount) {
+		t.Fatalf("expected a transition for every state and class, got %d", len(transitions))
+	}
+
+	expected := []LexerTransition{
+		{From: "q0", Class: "{unidade}", Action: "push", To: "q2 {unidade}"},
+		{From: "q0", Class: "{operador}", Action: "operator", To: "q1 {operador}"},
+		{From: "q0", Class: "{outra}", Action: "error", To: "q0"},
+		{From: "q5 cento", Class: "e", Action: "skip", To: "q7 {centena} e"},
+		{From: "q9 {milhar}", Class: "mil", Action: "push", To: "q9 {milhar}"},
+		{From: "q9 {milhar}", Class: "{operador}", Action: "finish", To: "q0"},
+		{From: "q11 zero", Class: "{unidade}", Action: "error", To: "q0"},
+	}
+
+	for _, transition := range expected {
+		if !slices.Contains(transitions, transition) {
+			t.Errorf("expected transition %+v", transition)
+		}
+	}
+}
+
+func TestLexTableErrors(t *testing.T) {
+	for s := stateStart; s < lexStateCount; s++ {
+		for c := classOther; c < wordClassCount; c++ {
+			transition := s.transition(c)
+
+			if transition.action == actionError && (transition.code == "" || transition.message == "") {
+				t.Errorf("state %s, class %s: error transition without code or message", lexTable[s].name, wordClassNames[c])
+			}
+
+			if transition.action != actionError && transition.code != "" {
+				t.Errorf("state %s, class %s: error code on a %s transition", lexTable[s].name, wordClassNames[c], lexActionNames[transition.action])
+			}
+		}
+	}
+}
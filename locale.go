@@ -0,0 +1,39 @@
+package spellnumber
+
+import "math/big"
+
+// Locale is the variant of Portuguese read by the lexer and written by the
+// speller.
+type Locale int
+
+const (
+	// PtBR is Brazilian Portuguese, with the short scale: "bilhao" is 10^9
+	PtBR Locale = iota
+	// PtPT is European Portuguese, with the long scale: 10^9 is "mil
+	// milhoes" and "biliao" is 10^12
+	PtPT
+)
+
+// scaleValue returns the value of the scale name of the given rank, from 1
+// for "milhao": 10^(3n+3) in the short scale and 10^(6n) in the long scale.
+func (l Locale) scaleValue(rank int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(l.scaleExponent(rank))), nil)
+}
+
+// scaleExponent returns the power of ten of the scale name of the given rank.
+func (l Locale) scaleExponent(rank int) int {
+	if l == PtPT {
+		return 6 * rank
+	}
+
+	return 3*rank + 3
+}
+
+// scaleName returns the canonical name of the scale in the locale.
+func (l Locale) scaleName(scale Scale) ScaleName {
+	if l == PtPT {
+		return scale.Long
+	}
+
+	return scale.Short
+}
@@ -0,0 +1,29 @@
+package spellnumber
+
+import "testing"
+
+func TestLocaleScaleValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   Locale
+		rank     int
+		expected string
+	}{
+		{name: "Milhao in pt-BR", locale: PtBR, rank: 1, expected: "1000000"},
+		{name: "Milhao in pt-PT", locale: PtPT, rank: 1, expected: "1000000"},
+		{name: "Bilhao in pt-BR", locale: PtBR, rank: 2, expected: "1000000000"},
+		{name: "Biliao in pt-PT", locale: PtPT, rank: 2, expected: "1000000000000"},
+		{name: "Trilhao in pt-BR", locale: PtBR, rank: 3, expected: "1000000000000"},
+		{name: "Triliao in pt-PT", locale: PtPT, rank: 3, expected: "1000000000000000000"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.locale.scaleValue(test.rank).String()
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package spellnumber
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Noun is a noun counted by SpellWithNoun, singular and plural, with the
+// gender the quantity agrees with, as in "duas toneladas".
+type Noun struct {
+	Singular string
+	Plural   string
+	Gender   Gender
+}
+
+// SpellWithNoun spells number followed by noun, in its gender and singular only
+// for one, as in "duas toneladas" or "menos um grau". Round scale names take
+// "de" before the noun, as in "dois milhoes de pessoas", while "mil" does not:
+// "duas mil pessoas".
+func (s Speller) SpellWithNoun(number *big.Int, noun Noun) string {
+	builder := strings.Builder{}
+
+	s.writeNoun(&builder, number, noun)
+
+	return s.output(builder.String())
+}
+
+// writeNoun writes a quantity in the gender of its noun, followed by the noun,
+// singular only for one, and after "de" when the quantity ends in a scale name,
+// as in "um milhao de reais".
+func (s Speller) writeNoun(builder *strings.Builder, quantity *big.Int, noun Noun) {
+	s.gender = noun.Gender
+
+	builder.WriteString(s.Spell(new(big.Int).Set(quantity)))
+
+	if quantity.Sign() != 0 && s.endsInScale(new(big.Int).Abs(quantity)) {
+		builder.WriteString(" ")
+		builder.WriteString(s.of)
+	}
+
+	builder.WriteString(" ")
+
+	if quantity.CmpAbs(big.NewInt(1)) == 0 {
+		builder.WriteString(noun.Singular)
+	} else {
+		builder.WriteString(noun.Plural)
+	}
+}
@@ -0,0 +1,103 @@
+package spellnumber
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSpellerSpellWithNoun(t *testing.T) {
+	pessoa := Noun{"pessoa", "pessoas", Feminine}
+	tonelada := Noun{"tonelada", "toneladas", Feminine}
+	grau := Noun{"grau", "graus", Masculine}
+
+	tests := []struct {
+		name     string
+		input    string
+		noun     Noun
+		expected string
+	}{
+		{
+			name:     "Singular",
+			input:    "1",
+			noun:     pessoa,
+			expected: "uma pessoa",
+		},
+		{
+			name:     "Feminine plural",
+			input:    "2",
+			noun:     tonelada,
+			expected: "duas toneladas",
+		},
+		{
+			name:     "Zero",
+			input:    "0",
+			noun:     pessoa,
+			expected: "zero pessoas",
+		},
+		{
+			name:     "Negative one",
+			input:    "-1",
+			noun:     grau,
+			expected: "menos um grau",
+		},
+		{
+			name:     "Round million",
+			input:    "1000000",
+			noun:     pessoa,
+			expected: "um milhão de pessoas",
+		},
+		{
+			name:     "Round millions",
+			input:    "2000000",
+			noun:     pessoa,
+			expected: "dois milhões de pessoas",
+		},
+		{
+			name:     "Thousands without de",
+			input:    "2000",
+			noun:     pessoa,
+			expected: "duas mil pessoas",
+		},
+		{
+			name:     "Millions and thousands",
+			input:    "2500000",
+			noun:     pessoa,
+			expected: "dois milhões e quinhentas mil pessoas",
+		},
+		{
+			name:     "Millions and units",
+			input:    "1000001",
+			noun:     pessoa,
+			expected: "um milhão e uma pessoas",
+		},
+		{
+			name:     "Negative millions",
+			input:    "-3000000",
+			noun:     grau,
+			expected: "menos três milhões de graus",
+		},
+	}
+
+	speller := NewSpeller()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, _ := new(big.Int).SetString(tt.input, 10)
+
+			if actual := speller.SpellWithNoun(number, tt.noun); actual != tt.expected {
+				t.Errorf("SpellWithNoun(%s) = %q, want %q", tt.input, actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellWithNounLocale(t *testing.T) {
+	speller := NewSpeller()
+	speller.SetLocale(PtPT)
+
+	number, _ := new(big.Int).SetString("1000000000000", 10)
+
+	if actual := speller.SpellWithNoun(number, Noun{"pessoa", "pessoas", Feminine}); actual != "um bilião de pessoas" {
+		t.Errorf("SpellWithNoun(10^12) = %q, want %q", actual, "um bilião de pessoas")
+	}
+}
@@ -2,17 +2,21 @@ package spellnumber
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"math/big"
 	"os"
-	"strings"
 )
 
 type Parser struct {
-	index   int
-	tokens  []Token
-	verbose bool
+	index    int
+	tokens   []Token
+	verbose  bool
+	recovery bool
+	// exact evaluates divisions as fractions instead of integer divisions
+	exact bool
+	errs  []error
 }
 
 func NewParser(tokens []Token) *Parser {
@@ -23,7 +27,77 @@ func (p *Parser) SetVerbose(verbose bool) {
 	p.verbose = verbose
 }
 
+// SetRecovery makes Parse go on after an error, so every lexer and parser
+// error is reported at once. Lexer error tokens are skipped, missing operands
+// are taken as zero and tokens left after the expression are reported.
+func (p *Parser) SetRecovery(recovery bool) {
+	p.recovery = recovery
+}
+
+// Parse evaluates the tokens as integers, so "dez dividido por quatro" is 2.
+// An expression holding a decimal number is evaluated exactly, as by
+// ParseRat, and must come to an integer.
 func (p *Parser) Parse() (*big.Int, error) {
+	p.exact = false
+
+	for _, token := range p.tokens {
+		if token.Type == TOKEN_NUMBER_PARSED && token.Number == nil {
+			p.exact = true
+		}
+	}
+
+	result, err := p.parse()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.IsInt() {
+		return nil, &ParseError{Code: ErrNotInteger, Lexeme: ratString(result), Message: fmt.Sprintf("Resultado '%s' não é inteiro", ratString(result))}
+	}
+
+	return new(big.Int).Set(result.Num()), nil
+}
+
+// ParseRat evaluates the tokens exactly, so "dez dividido por quatro" is 5/2.
+func (p *Parser) ParseRat() (*big.Rat, error) {
+	p.exact = true
+
+	return p.parse()
+}
+
+// ParseMoney evaluates the tokens exactly, as ParseRat, as an amount in the
+// currency of their money amounts, so "dez reais e cinquenta centavos vezes
+// tres" is 31.50 BRL. The money amounts must all be in the same currency.
+func (p *Parser) ParseMoney() (Money, error) {
+	result, err := p.ParseRat()
+
+	if err != nil {
+		return Money{}, err
+	}
+
+	code := ""
+
+	for _, token := range p.tokens {
+		if token.Currency == "" {
+			continue
+		}
+
+		if code != "" && token.Currency != code {
+			return Money{}, p.errorAt(token, ErrMixedCurrencies, fmt.Sprintf("Moeda '%s' diferente de '%s'", token.Currency, code))
+		}
+
+		code = token.Currency
+	}
+
+	if code == "" {
+		return Money{}, &ParseError{Code: ErrNoCurrency, Lexeme: ratString(result), Message: "Expressão sem valor monetário"}
+	}
+
+	return Money{Amount: result, Currency: code}, nil
+}
+
+func (p *Parser) parse() (*big.Rat, error) {
 	if !p.verbose {
 		log.SetOutput(io.Discard)
 
@@ -31,25 +105,80 @@ func (p *Parser) Parse() (*big.Int, error) {
 	}
 
 	if len(p.tokens) == 0 {
-		return big.NewInt(0), nil
+		return new(big.Rat), nil
 	}
 
-	errorTokens := make([]string, 0, len(p.tokens))
+	lexErrors := make([]error, 0)
 
 	for _, token := range p.tokens {
 		if token.Type == TOKEN_ERROR {
-			errorTokens = append(errorTokens, token.Spell)
+			lexErrors = append(lexErrors, token.lexError())
 		}
 	}
 
-	if val := strings.Join(errorTokens, "; "); val != "" {
-		return nil, errors.New(val)
+	if p.recovery {
+		return p.recoveryParse(lexErrors)
+	}
+
+	if len(lexErrors) > 0 {
+		return nil, errors.Join(lexErrors...)
 	}
 
 	return p.expression()
 }
 
-func (p *Parser) expression() (*big.Int, error) {
+func (p *Parser) recoveryParse(lexErrors []error) (*big.Rat, error) {
+	tokens := make([]Token, 0, len(p.tokens))
+
+	for _, token := range p.tokens {
+		if token.Type != TOKEN_ERROR {
+			tokens = append(tokens, token)
+		}
+	}
+
+	// Only lexer errors, which say it all
+	if len(tokens) == 0 {
+		return nil, errors.Join(lexErrors...)
+	}
+
+	p.tokens, p.index, p.errs = tokens, 0, lexErrors
+
+	// Errors are recorded in p.errs instead of being returned
+	result, _ := p.expression()
+
+	for p.sym() != TOKEN_EOF {
+		p.fail(p.newError(ErrUnexpectedToken, "Token inesperado após o fim da expressão", "{operador}"))
+
+		// A token that may start an expression is read as one, as "tres" in
+		// "dois tres"
+		if !startsExpression(p.sym()) {
+			p.nextSym()
+		}
+
+		if p.sym() != TOKEN_EOF {
+			p.expression()
+		}
+	}
+
+	if len(p.errs) > 0 {
+		return nil, errors.Join(p.errs...)
+	}
+
+	return result, nil
+}
+
+// startsExpression reports whether an expression may start with a token of
+// the type.
+func startsExpression(tokenType TokenType) bool {
+	switch tokenType {
+	case TOKEN_NUMBER_PARSED, TOKEN_LEFT_BRACKET, TOKEN_PLUS, TOKEN_MINUS, TOKEN_FACTORIAL:
+		return true
+	}
+
+	return false
+}
+
+func (p *Parser) expression() (*big.Rat, error) {
 	sym := p.sym()
 
 	if sym == TOKEN_PLUS || sym == TOKEN_MINUS {
@@ -63,7 +192,7 @@ func (p *Parser) expression() (*big.Int, error) {
 	}
 
 	if sym == TOKEN_MINUS {
-		first = first.Mul(big.NewInt(-1), first)
+		first = new(big.Rat).Neg(first)
 	}
 
 	acceptedSymbols := map[TokenType]bool{
@@ -87,25 +216,25 @@ func (p *Parser) expression() (*big.Int, error) {
 		}
 
 		if op == TOKEN_MINUS {
-			first = big.NewInt(0).Sub(first, second)
+			first = new(big.Rat).Sub(first, second)
 
 			continue
 		}
 
 		if op == TOKEN_PLUS {
-			first = big.NewInt(0).Add(first, second)
+			first = new(big.Rat).Add(first, second)
 
 			continue
 
 		}
 
-		return nil, errors.New("Esperado um operador: mais ou menos")
+		return nil, p.newError(ErrExpectedOperator, "Esperado um operador: mais ou menos", "mais", "menos")
 	}
 
 	return first, nil
 }
 
-func (p *Parser) term() (*big.Int, error) {
+func (p *Parser) term() (*big.Rat, error) {
 	first, err := p.factorial()
 
 	if err != nil {
@@ -124,7 +253,7 @@ func (p *Parser) term() (*big.Int, error) {
 			break
 		}
 
-		op := p.sym()
+		op := p.current()
 
 		p.nextSym()
 
@@ -134,34 +263,93 @@ func (p *Parser) term() (*big.Int, error) {
 			return nil, err
 		}
 
-		if op == TOKEN_TIMES {
-			first = big.NewInt(1).Mul(first, second)
+		if op.Type == TOKEN_TIMES {
+			first = new(big.Rat).Mul(first, second)
 			continue
 		}
 
-		if op == TOKEN_DIVIDE {
-			first = big.NewInt(1).Div(first, second)
+		if op.Type == TOKEN_DIVIDE {
+			if first, err = p.divide(op, first, second); err != nil {
+				return nil, err
+			}
+
 			continue
 		}
 
-		if op == TOKEN_POWER {
-			first = big.NewInt(1).Exp(first, second, nil)
+		if op.Type == TOKEN_POWER {
+			if first, err = p.power(op, first, second); err != nil {
+				return nil, err
+			}
+
 			continue
 		}
 
-		if op == TOKEN_MOD {
-			first = big.NewInt(1).Mod(first, second)
+		if op.Type == TOKEN_MOD {
+			if first, err = p.mod(op, first, second); err != nil {
+				return nil, err
+			}
+
 			continue
 		}
 
-		return nil, errors.New("Esperado um dos operadores: vezes, dividido por, elevado por, mod")
+		return nil, p.newError(ErrExpectedOperator, "Esperado um dos operadores: vezes, dividido por, elevado por, mod", "vezes", "dividido por", "elevado por", "mod")
 	}
 
 	return first, nil
 }
 
-func (p *Parser) factorial() (*big.Int, error) {
+func (p *Parser) divide(op Token, first, second *big.Rat) (*big.Rat, error) {
+	if second.Sign() == 0 {
+		return p.fail(p.errorAt(op, ErrDivisionByZero, "Divisão por zero"))
+	}
+
+	if !p.exact {
+		return new(big.Rat).SetInt(new(big.Int).Div(first.Num(), second.Num())), nil
+	}
+
+	return new(big.Rat).Quo(first, second), nil
+}
+
+func (p *Parser) power(op Token, base, exponent *big.Rat) (*big.Rat, error) {
+	if !exponent.IsInt() {
+		return p.fail(p.errorAt(op, ErrNotInteger, fmt.Sprintf("Expoente '%s' não é inteiro", ratString(exponent))))
+	}
+
+	if !p.exact {
+		return new(big.Rat).SetInt(new(big.Int).Exp(base.Num(), exponent.Num(), nil)), nil
+	}
+
+	n := new(big.Int).Abs(exponent.Num())
+
+	result := new(big.Rat).SetFrac(new(big.Int).Exp(base.Num(), n, nil), new(big.Int).Exp(base.Denom(), n, nil))
+
+	if exponent.Sign() >= 0 {
+		return result, nil
+	}
+
+	if result.Sign() == 0 {
+		return p.fail(p.errorAt(op, ErrDivisionByZero, "Divisão por zero"))
+	}
+
+	return result.Inv(result), nil
+}
+
+func (p *Parser) mod(op Token, first, second *big.Rat) (*big.Rat, error) {
+	if !first.IsInt() || !second.IsInt() {
+		return p.fail(p.errorAt(op, ErrNotInteger, "O resto da divisão só é definido para inteiros"))
+	}
+
+	if second.Sign() == 0 {
+		return p.fail(p.errorAt(op, ErrDivisionByZero, "Divisão por zero"))
+	}
+
+	return new(big.Rat).SetInt(new(big.Int).Mod(first.Num(), second.Num())), nil
+}
+
+func (p *Parser) factorial() (*big.Rat, error) {
 	if p.sym() == TOKEN_FACTORIAL {
+		op := p.current()
+
 		p.nextSym()
 
 		result, err := p.factorial()
@@ -170,13 +358,36 @@ func (p *Parser) factorial() (*big.Int, error) {
 			return nil, err
 		}
 
-		return big.NewInt(1).MulRange(1, result.Int64()), nil
+		return p.factorialOf(op, result)
+	}
+
+	result, err := p.parenthesis()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Typed expressions use the postfix form, as in "5!"
+	for p.sym() == TOKEN_FACTORIAL {
+		if result, err = p.factorialOf(p.current(), result); err != nil {
+			return nil, err
+		}
+
+		p.nextSym()
+	}
+
+	return result, nil
+}
+
+func (p *Parser) factorialOf(op Token, n *big.Rat) (*big.Rat, error) {
+	if !n.IsInt() {
+		return p.fail(p.errorAt(op, ErrNotInteger, fmt.Sprintf("Fatorial de '%s', que não é inteiro", ratString(n))))
 	}
 
-	return p.parenthesis()
+	return new(big.Rat).SetInt(new(big.Int).MulRange(1, n.Num().Int64())), nil
 }
 
-func (p *Parser) parenthesis() (*big.Int, error) {
+func (p *Parser) parenthesis() (*big.Rat, error) {
 	sym := p.sym()
 
 	if sym == TOKEN_LEFT_BRACKET {
@@ -189,7 +400,11 @@ func (p *Parser) parenthesis() (*big.Int, error) {
 		}
 
 		if p.sym() != TOKEN_RIGHT_BRACKET {
-			return nil, errors.New("Esperado fecha parentese(s)")
+			if _, err := p.fail(p.newError(ErrUnclosedParenthesis, "Esperado fecha parentese(s)", "fecha parentese")); err != nil {
+				return nil, err
+			}
+
+			return exp, nil
 		}
 
 		p.nextSym()
@@ -197,26 +412,81 @@ func (p *Parser) parenthesis() (*big.Int, error) {
 		return exp, nil
 	}
 
-	defer p.nextSym()
-
 	return p.value()
 }
 
-func (p *Parser) value() (*big.Int, error) {
+func (p *Parser) value() (*big.Rat, error) {
 	if p.sym() != TOKEN_NUMBER_PARSED {
-		return nil, errors.New("Esperado um número")
+		// The token is left for the caller, as it may be an operator
+		return p.fail(p.newError(ErrExpectedNumber, "Esperado um número", "{número}"))
 	}
 
-	return p.tokens[p.index].Number, nil
+	token := p.tokens[p.index]
+
+	p.nextSym()
+
+	// Tokens built by hand may only carry Number
+	if token.Rat == nil {
+		return new(big.Rat).SetInt(token.Number), nil
+	}
+
+	return token.Rat, nil
 }
 func (p *Parser) sym() TokenType {
 	if len(p.tokens) == 0 || p.index >= len(p.tokens) {
 		return TOKEN_EOF
 	}
 
+	// A line break ends the expression, as in the streams built by Lexer.Tokens
+	if p.tokens[p.index].Type == TOKEN_EOL {
+		return TOKEN_EOF
+	}
+
 	return p.tokens[p.index].Type
 }
 
 func (p *Parser) nextSym() {
 	p.index++
 }
+
+// current returns the token being read. Past the last token it returns an
+// EOF token located where the input ends.
+func (p *Parser) current() Token {
+	if p.index < len(p.tokens) {
+		return p.tokens[p.index]
+	}
+
+	if len(p.tokens) == 0 {
+		return Token{Type: TOKEN_EOF}
+	}
+
+	end := p.tokens[len(p.tokens)-1].End
+
+	return Token{Type: TOKEN_EOF, Pos: end, End: end}
+}
+
+// fail returns err, or records it and carries on with zero in recovery mode.
+func (p *Parser) fail(err *ParseError) (*big.Rat, error) {
+	if !p.recovery {
+		return nil, err
+	}
+
+	p.errs = append(p.errs, err)
+
+	return new(big.Rat), nil
+}
+
+func (p *Parser) newError(code ErrorCode, message string, expected ...string) *ParseError {
+	return p.errorAt(p.current(), code, message, expected...)
+}
+
+func (p *Parser) errorAt(token Token, code ErrorCode, message string, expected ...string) *ParseError {
+	return &ParseError{
+		Code:     code,
+		Pos:      token.Pos,
+		End:      token.End,
+		Lexeme:   token.Value,
+		Expected: expected,
+		Message:  message,
+	}
+}
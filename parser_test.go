@@ -3,6 +3,7 @@ package spellnumber
 import (
 	"errors"
 	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -35,6 +36,16 @@ func TestParserParse(t *testing.T) {
 			},
 			expected: big.NewInt(24),
 		},
+		{
+			name: "Fatorial posfixo",
+			input: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Number: big.NewInt(4)},
+				{Type: TOKEN_FACTORIAL, Value: "!"},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Number: big.NewInt(1)},
+			},
+			expected: big.NewInt(25),
+		},
 		{
 			name: "100 * (20 + 10) - 1 = 2999",
 			input: []Token{
@@ -127,6 +138,42 @@ func TestParserParse(t *testing.T) {
 			input:    []Token{},
 			expected: big.NewInt(0),
 		},
+		{
+			name: "divisão inteira",
+			input: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Number: big.NewInt(10)},
+				{Type: TOKEN_DIVIDE, Value: "/"},
+				{Type: TOKEN_NUMBER_PARSED, Number: big.NewInt(4)},
+			},
+			expected: big.NewInt(2),
+		},
+		{
+			name: "decimal com resultado inteiro",
+			input: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Rat: big.NewRat(5, 2)},
+				{Type: TOKEN_TIMES, Value: "*"},
+				{Type: TOKEN_NUMBER_PARSED, Number: big.NewInt(4)},
+			},
+			expected: big.NewInt(10),
+		},
+		{
+			name: "decimal com resultado decimal",
+			input: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Rat: big.NewRat(5, 2)},
+				{Type: TOKEN_PLUS, Value: "+"},
+				{Type: TOKEN_NUMBER_PARSED, Number: big.NewInt(4)},
+			},
+			expectedError: errors.New("Resultado '6.5' não é inteiro"),
+		},
+		{
+			name: "divisão por zero",
+			input: []Token{
+				{Type: TOKEN_NUMBER_PARSED, Number: big.NewInt(10)},
+				{Type: TOKEN_DIVIDE, Value: "/"},
+				{Type: TOKEN_NUMBER_PARSED, Number: big.NewInt(0)},
+			},
+			expectedError: errors.New("Divisão por zero"),
+		},
 	}
 
 	for _, test := range tests {
@@ -145,3 +192,300 @@ func TestParserParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParserParseRat(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      *big.Rat
+		expectedError ErrorCode
+	}{
+		{
+			name:     "divisão exata",
+			input:    "dez dividido por quatro",
+			expected: big.NewRat(5, 2),
+		},
+		{
+			name:     "divisão seguida de produto",
+			input:    "um dividido por três vezes três",
+			expected: big.NewRat(1, 1),
+		},
+		{
+			name:     "frações",
+			input:    "dois terços mais cinco onze avos",
+			expected: big.NewRat(37, 33),
+		},
+		{
+			name:     "vírgula",
+			input:    "três vírgula catorze vezes dois",
+			expected: big.NewRat(628, 100),
+		},
+		{
+			name:     "e meio",
+			input:    "dois e meio ao quadrado",
+			expected: big.NewRat(25, 4),
+		},
+		{
+			name:     "expoente negativo",
+			input:    "dois elevado a abre parentese zero menos dois fecha parentese",
+			expected: big.NewRat(1, 4),
+		},
+		{
+			name:          "expoente decimal",
+			input:         "dois elevado a um e meio",
+			expectedError: ErrNotInteger,
+		},
+		{
+			name:          "fatorial decimal",
+			input:         "fatorial de dois e meio",
+			expectedError: ErrNotInteger,
+		},
+		{
+			name:          "resto decimal",
+			input:         "dois e meio mod dois",
+			expectedError: ErrNotInteger,
+		},
+		{
+			name:          "divisão por zero",
+			input:         "um dividido por zero",
+			expectedError: ErrDivisionByZero,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens, err := NewLexer(nil).ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result, err := NewParser(tokens).ParseRat()
+
+			if test.expectedError != "" {
+				if !errors.Is(err, test.expectedError) {
+					t.Errorf("expected error %q, got %v", test.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Cmp(test.expected) != 0 {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestParserParseMoney(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      string
+		expectedError ErrorCode
+	}{
+		{
+			name:     "reais e centavos vezes tres",
+			input:    "dez reais e cinquenta centavos vezes três",
+			expected: "31.50 BRL",
+		},
+		{
+			name:     "e cinquenta",
+			input:    "dez reais e cinquenta",
+			expected: "10.50 BRL",
+		},
+		{
+			name:     "centavos",
+			input:    "cinquenta centavos mais um real",
+			expected: "1.50 BRL",
+		},
+		{
+			name:     "de reais",
+			input:    "um milhão de reais dividido por quatro",
+			expected: "250000.00 BRL",
+		},
+		{
+			name:     "arredondado na exibição",
+			input:    "dez reais dividido por três",
+			expected: "3.33 BRL",
+		},
+		{
+			name:          "sem moeda",
+			input:         "dez mais dois",
+			expectedError: ErrNoCurrency,
+		},
+		{
+			name:          "valor inválido",
+			input:         "dez reais e cento e vinte centavos",
+			expectedError: ErrInvalidNumber,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens, err := NewLexer(nil).ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result, err := NewParser(tokens).ParseMoney()
+
+			if test.expectedError != "" {
+				if !errors.Is(err, test.expectedError) {
+					t.Errorf("expected error %q, got %v", test.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.String() != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestParserParseMoneyMixedCurrencies(t *testing.T) {
+	tokens, err := NewLexer(nil).ParseLine("dez reais mais dois")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A token read in another currency
+	tokens[2].Currency = "USD"
+
+	if _, err := NewParser(tokens).ParseMoney(); !errors.Is(err, ErrMixedCurrencies) {
+		t.Errorf("expected error %q, got %v", ErrMixedCurrencies, err)
+	}
+}
+
+func TestParserTokensStream(t *testing.T) {
+	lexer := NewLexerFromReader(strings.NewReader("dois mais tres\ncem"))
+
+	tokens := []Token{}
+
+	for token, err := range lexer.Tokens() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	result, err := NewParser(tokens).Parse()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("expected 5, got %v", result)
+	}
+}
+
+func TestParserRecovery(t *testing.T) {
+	lexer := NewLexer(nil)
+	lexer.SetRecovery(true)
+
+	tokens, err := lexer.ParseLine("dois mais xyz vezes tres mais mais abre parentese dez")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parser := NewParser(tokens)
+	parser.SetRecovery(true)
+
+	_, err = parser.Parse()
+
+	// "xyz" is unknown, "mais vezes" and "mais mais" miss a number and the
+	// parenthesis is never closed
+	codes := []ErrorCode{ErrUnknownLexeme, ErrExpectedNumber, ErrExpectedNumber, ErrUnclosedParenthesis}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+
+	if !ok || len(joined.Unwrap()) != len(codes) {
+		t.Fatalf("expected %d errors, got %v", len(codes), err)
+	}
+
+	for i, err := range joined.Unwrap() {
+		if !errors.Is(err, codes[i]) {
+			t.Errorf("expected error %q, got %v", codes[i], err)
+		}
+	}
+}
+
+func TestParserRecoveryErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		codes []ErrorCode
+	}{
+		{
+			name:  "Operator after cento",
+			input: "cento mais dois",
+			codes: []ErrorCode{ErrExpectedWord},
+		},
+		{
+			name:  "Number left after the expression",
+			input: "dois dividido xyz tres",
+			codes: []ErrorCode{ErrExpectedWord, ErrUnexpectedToken},
+		},
+		{
+			name:  "Operator left after the expression",
+			input: "dois fecha parentese",
+			codes: []ErrorCode{ErrUnexpectedToken},
+		},
+		{
+			name:  "Only lexer errors",
+			input: "q",
+			codes: []ErrorCode{ErrUnknownLexeme},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lexer := NewLexer(nil)
+			lexer.SetRecovery(true)
+
+			tokens, err := lexer.ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			parser := NewParser(tokens)
+			parser.SetRecovery(true)
+
+			_, err = parser.Parse()
+
+			joined, ok := err.(interface{ Unwrap() []error })
+
+			if !ok || len(joined.Unwrap()) != len(test.codes) {
+				t.Fatalf("expected %d errors, got %v", len(test.codes), err)
+			}
+
+			for i, err := range joined.Unwrap() {
+				if !errors.Is(err, test.codes[i]) {
+					t.Errorf("expected error %q, got %v", test.codes[i], err)
+				}
+
+				var parseError *ParseError
+
+				if errors.As(err, &parseError) && parseError.Pos.Line == 0 {
+					t.Errorf("error without position: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package spellnumber
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ScaleName is the canonical spelling of a scale name, singular and plural.
+type ScaleName struct {
+	Singular string
+	Plural   string
+}
+
+// Scale is a scale name shared by the lexer and the speller. The speller
+// writes its canonical names, Short in PtBR and Long in PtPT, and Ordinal;
+// the lexer reads those and every variant.
+type Scale struct {
+	Short           ScaleName
+	Long            ScaleName
+	Variants        []string
+	Ordinal         string
+	OrdinalVariants []string
+}
+
+// Scales is the registry of the scale names by rank, from 1 for "milhão".
+// Its value depends on the locale, see Locale. The ranks missing from it, up
+// to 999, take the names built from their Latin prefixes, as in
+// "vigintilhão" for rank 20.
+var Scales = map[int]Scale{
+	1: {
+		Short:   ScaleName{"milhão", "milhões"},
+		Long:    ScaleName{"milhão", "milhões"},
+		Ordinal: "milionésimo",
+	},
+	2: {
+		Short:   ScaleName{"bilhão", "bilhões"},
+		Long:    ScaleName{"bilião", "biliões"},
+		Ordinal: "bilionésimo",
+	},
+	3: {
+		Short:   ScaleName{"trilhão", "trilhões"},
+		Long:    ScaleName{"trilião", "triliões"},
+		Ordinal: "trilionésimo",
+	},
+	4: {
+		Short:           ScaleName{"quatrilhão", "quatrilhões"},
+		Long:            ScaleName{"quatrilião", "quatriliões"},
+		Variants:        []string{"quadrilhão", "quadrilhões", "quadrilião", "quadriliões"},
+		Ordinal:         "quatrilionésimo",
+		OrdinalVariants: []string{"quadrilionésimo"},
+	},
+	5: {
+		Short:   ScaleName{"quintilhão", "quintilhões"},
+		Long:    ScaleName{"quintilião", "quintiliões"},
+		Ordinal: "quintilionésimo",
+	},
+	6: {
+		Short:   ScaleName{"sextilhão", "sextilhões"},
+		Long:    ScaleName{"sextilião", "sextiliões"},
+		Ordinal: "sextilionésimo",
+	},
+	7: {
+		Short:           ScaleName{"setilhão", "setilhões"},
+		Long:            ScaleName{"setilião", "setiliões"},
+		Variants:        []string{"septilhão", "septilhões", "septilião", "septiliões"},
+		Ordinal:         "setilionésimo",
+		OrdinalVariants: []string{"septilionésimo"},
+	},
+	8: {
+		Short:   ScaleName{"octilhão", "octilhões"},
+		Long:    ScaleName{"octilião", "octiliões"},
+		Ordinal: "octilionésimo",
+	},
+	9: {
+		Short:   ScaleName{"nonilhão", "nonilhões"},
+		Long:    ScaleName{"nonilião", "noniliões"},
+		Ordinal: "nonilionésimo",
+	},
+	10: {
+		Short:   ScaleName{"decilhão", "decilhões"},
+		Long:    ScaleName{"decilião", "deciliões"},
+		Ordinal: "decilionésimo",
+	},
+	11: {
+		Short:   ScaleName{"undecilhão", "undecilhões"},
+		Long:    ScaleName{"undecilião", "undeciliões"},
+		Ordinal: "undecilionésimo",
+	},
+	12: {
+		Short:   ScaleName{"duodecilhão", "duodecilhões"},
+		Long:    ScaleName{"duodecilião", "duodeciliões"},
+		Ordinal: "duodecilionésimo",
+	},
+	13: {
+		Short:           ScaleName{"tredecilhão", "tredecilhões"},
+		Long:            ScaleName{"tredecilião", "tredeciliões"},
+		Variants:        []string{"tridecilhão", "tridecilhões", "tridecilião", "trideciliões"},
+		Ordinal:         "tredecilionésimo",
+		OrdinalVariants: []string{"tridecilionésimo"},
+	},
+	14: {
+		Short:           ScaleName{"quatrodecilhão", "quatrodecilhões"},
+		Long:            ScaleName{"quatrodecilião", "quatrodeciliões"},
+		Variants:        []string{"quatradecilhão", "quatradecilhões", "quatordecilhão", "quatordecilhões", "quatuordecilhão", "quatuordecilhões"},
+		Ordinal:         "quatrodecilionésimo",
+		OrdinalVariants: []string{"quatordecilionésimo"},
+	},
+}
+
+// words returns every spelling of the scale read by the lexer.
+func (s Scale) words() []string {
+	return append([]string{s.Short.Singular, s.Short.Plural, s.Long.Singular, s.Long.Plural}, s.Variants...)
+}
+
+// ordinals returns every spelling of the ordinal of the scale read by the
+// lexer.
+func (s Scale) ordinals() []string {
+	return append([]string{s.Ordinal}, s.OrdinalVariants...)
+}
+
+// equal reports whether s and other have the same names.
+func (s Scale) equal(other Scale) bool {
+	return s.Short == other.Short && s.Long == other.Long && s.Ordinal == other.Ordinal &&
+		slices.Equal(s.Variants, other.Variants) && slices.Equal(s.OrdinalVariants, other.OrdinalVariants)
+}
+
+// clone returns a copy of s that does not share its slices.
+func (s Scale) clone() Scale {
+	s.Variants = slices.Clone(s.Variants)
+	s.OrdinalVariants = slices.Clone(s.OrdinalVariants)
+
+	return s
+}
+
+// scalesCache holds a value built from Scales, such as the vocabulary of the
+// lexer, along with a copy of the Scales it was built from. The value is
+// built once and shared, and built again when Scales has been edited since,
+// so the lexer and the speller keep agreeing on the registry.
+type scalesCache[T any] struct {
+	mu     sync.RWMutex
+	scales map[int]Scale
+	value  T
+}
+
+// get returns the value built by build from the current Scales.
+func (c *scalesCache[T]) get(build func() T) T {
+	c.mu.RLock()
+	value, current := c.value, c.scales != nil && sameScales(c.scales)
+	c.mu.RUnlock()
+
+	if current {
+		return value
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.scales == nil || !sameScales(c.scales) {
+		c.value = build()
+		c.scales = make(map[int]Scale, len(Scales))
+
+		for rank, scale := range Scales {
+			c.scales[rank] = scale.clone()
+		}
+	}
+
+	return c.value
+}
+
+// sameScales reports whether scales holds the same names as Scales.
+func sameScales(scales map[int]Scale) bool {
+	return maps.EqualFunc(scales, Scales, Scale.equal)
+}
+
+// maxScaleRank is the rank of the largest scale name, built from the Latin
+// prefixes of 999. Past it, numbers are spelled as multiples of that name.
+const maxScaleRank = 999
+
+// latinUnits, latinTens and latinHundreds are the Latin prefixes the scale
+// names past Scales are built from, units first, as in "unvigintilhão" for
+// rank 21 or "centilhão" for rank 100.
+var (
+	latinUnits    = [10]string{"", "un", "duo", "tre", "quatuor", "quin", "sex", "septen", "octo", "noven"}
+	latinTens     = [10]string{"", "deci", "viginti", "triginta", "quadraginta", "quinquaginta", "sexaginta", "septuaginta", "octoginta", "nonaginta"}
+	latinHundreds = [10]string{"", "centi", "ducenti", "trecenti", "quadringenti", "quingenti", "sescenti", "septingenti", "octingenti", "nongenti"}
+)
+
+// latinTresTens and latinTresHundreds mark the prefixes before which "tre"
+// takes an "s", after Conway and Wechsler, as in "tresvigintilhão" and
+// "trescentilhão", which is not "trecentilhão", rank 300.
+var (
+	latinTresTens     = [10]bool{2: true, 3: true, 4: true, 5: true, 8: true}
+	latinTresHundreds = [10]bool{1: true, 3: true, 4: true, 5: true, 8: true}
+)
+
+// latinRanks maps the stems of the systematic scale names, as in "vigint" for
+// "vigintilhão", to their ranks.
+var latinRanks = func() map[string]int {
+	ranks := make(map[string]int, maxScaleRank)
+
+	for rank := 10; rank <= maxScaleRank; rank++ {
+		ranks[latinStem(rank)] = rank
+	}
+
+	return ranks
+}()
+
+// latinStem returns the Latin prefix of a rank from 10 on, without the vowel
+// closing it.
+func latinStem(rank int) string {
+	units, tens, hundreds := rank%10, rank/10%10, rank/100
+
+	unitsPrefix := latinUnits[units]
+
+	if units == 3 && (tens > 0 && latinTresTens[tens] || tens == 0 && latinTresHundreds[hundreds]) {
+		unitsPrefix += "s"
+	}
+
+	prefix := unitsPrefix + latinTens[tens] + latinHundreds[hundreds]
+
+	return prefix[:len(prefix)-1]
+}
+
+// scaleAt returns the scale of a rank: the one in Scales or, up to
+// maxScaleRank, the one built from the Latin prefixes of the rank.
+func scaleAt(rank int) (Scale, bool) {
+	if scale, ok := Scales[rank]; ok {
+		return scale, true
+	}
+
+	if rank < 10 || rank > maxScaleRank {
+		return Scale{}, false
+	}
+
+	stem := latinStem(rank)
+
+	return Scale{
+		Short:   ScaleName{stem + "ilhão", stem + "ilhões"},
+		Long:    ScaleName{stem + "ilião", stem + "iliões"},
+		Ordinal: stem + "ilionésimo",
+	}, true
+}
+
+// latinScale reads a systematic scale name without accents, as in
+// "vigintilhoes", returning its rank and whether it is the ordinal, as in
+// "vigintilionesimo".
+func latinScale(word string) (rank int, ordinal bool, ok bool) {
+	for _, suffix := range []string{"ilhao", "ilhoes", "iliao", "ilioes"} {
+		if stem, found := strings.CutSuffix(word, suffix); found {
+			rank, ok = latinRanks[stem]
+
+			return rank, false, ok
+		}
+	}
+
+	if stem, found := strings.CutSuffix(word, "ilionesimo"); found {
+		rank, ok = latinRanks[stem]
+
+		return rank, true, ok
+	}
+
+	return 0, false, false
+}
@@ -0,0 +1,207 @@
+package spellnumber
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestScalesRoundTrip(t *testing.T) {
+	for _, locale := range []Locale{PtBR, PtPT} {
+		for _, rank := range []int{1, 2, 9, 10, 14, 15, 19, 20, 21, 23, 99, 100, 103, 110, 300, 998, maxScaleRank} {
+			value := new(big.Int).Mul(big.NewInt(2), locale.scaleValue(rank))
+
+			for _, number := range []*big.Int{locale.scaleValue(rank), value} {
+				speller := NewSpeller()
+				speller.SetLocale(locale)
+
+				spelled := speller.Spell(new(big.Int).Set(number))
+
+				lexer := NewLexer(nil)
+				lexer.SetLocale(locale)
+
+				tokens, err := lexer.ParseLine(spelled)
+
+				if err != nil {
+					t.Fatalf("%q: unexpected error: %v", spelled, err)
+				}
+
+				if len(tokens) != 1 || tokens[0].Number == nil || tokens[0].Number.Cmp(number) != 0 {
+					t.Errorf("%q: expected %v, got %v", spelled, number, tokens)
+				}
+			}
+		}
+	}
+}
+
+func TestScalesEditedAfterLexing(t *testing.T) {
+	lexer := NewLexer(nil)
+
+	read := func(input string) []Token {
+		tokens, err := lexer.ParseLine(input)
+
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", input, err)
+		}
+
+		return tokens
+	}
+
+	if tokens := read("dois contos"); len(tokens) == 0 || tokens[0].Type != TOKEN_NUMBER_PARSED || tokens[0].Value != "2" {
+		t.Fatalf("expected 'contos' to be unknown, got %v", tokens)
+	}
+
+	scale := Scales[1]
+
+	edited := scale.clone()
+	edited.Variants = append(edited.Variants, "conto", "contos")
+	Scales[1] = edited
+
+	t.Cleanup(func() { Scales[1] = scale })
+
+	for _, lexer := range []*Lexer{lexer, NewLexer(nil)} {
+		tokens, err := lexer.ParseLine("dois contos")
+
+		if err != nil || len(tokens) != 1 || tokens[0].Value != "2000000" {
+			t.Errorf("expected 2000000, got %v (%v)", tokens, err)
+		}
+	}
+
+	Scales[1] = scale
+
+	if tokens := read("dois contos"); len(tokens) != 2 || tokens[0].Value != "2" {
+		t.Errorf("expected 'contos' to be unknown again, got %v", tokens)
+	}
+}
+
+func TestScalesEditedAfterSpelling(t *testing.T) {
+	number := big.NewInt(2000000)
+
+	if actual := NewSpeller().Spell(number); actual != "dois milhões" {
+		t.Fatalf("expected %q, got %q", "dois milhões", actual)
+	}
+
+	scale := Scales[1]
+
+	edited := scale.clone()
+	edited.Short = ScaleName{"conto", "contos"}
+	Scales[1] = edited
+
+	t.Cleanup(func() { Scales[1] = scale })
+
+	if actual := NewSpeller().Spell(number); actual != "dois contos" {
+		t.Errorf("expected %q, got %q", "dois contos", actual)
+	}
+
+	Scales[1] = scale
+
+	if actual := NewSpeller().Spell(number); actual != "dois milhões" {
+		t.Errorf("expected %q, got %q", "dois milhões", actual)
+	}
+}
+
+func TestScalesVariants(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Quadrilhao", input: "um quadrilhão", expected: "1000000000000000"},
+		{name: "Quatrilhao", input: "um quatrilhão", expected: "1000000000000000"},
+		{name: "Tridecilhoes", input: "dois tridecilhões", expected: "2" + strings.Repeat("0", 42)},
+		{name: "Quatradecilhao", input: "um quatradecilhão", expected: "1" + strings.Repeat("0", 45)},
+		{name: "Quatrodecilhao", input: "um quatrodecilhão", expected: "1" + strings.Repeat("0", 45)},
+		{name: "Septilhao", input: "um septilhão", expected: "1" + strings.Repeat("0", 24)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens, err := NewLexer(nil).ParseLine(test.input)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tokens[0].Value != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, tokens[0].Value)
+			}
+		})
+	}
+}
+
+func TestScalesLatinNames(t *testing.T) {
+	for rank := 10; rank <= maxScaleRank; rank++ {
+		if _, ok := Scales[rank]; ok {
+			continue
+		}
+
+		scale, _ := scaleAt(rank)
+
+		for _, word := range []string{scale.Short.Singular, scale.Short.Plural, scale.Long.Singular, scale.Long.Plural, scale.Ordinal} {
+			if actual, ordinal, ok := latinScale(removeAccents(word)); !ok || actual != rank || ordinal != (word == scale.Ordinal) {
+				t.Errorf("latinScale(%q) = %d, %v, %v, want %d", word, actual, ordinal, ok, rank)
+			}
+		}
+	}
+}
+
+func TestScaleAt(t *testing.T) {
+	tests := []struct {
+		rank    int
+		short   ScaleName
+		long    ScaleName
+		ordinal string
+	}{
+		{rank: 1, short: ScaleName{"milhão", "milhões"}, long: ScaleName{"milhão", "milhões"}, ordinal: "milionésimo"},
+		{rank: 14, short: ScaleName{"quatrodecilhão", "quatrodecilhões"}, long: ScaleName{"quatrodecilião", "quatrodeciliões"}, ordinal: "quatrodecilionésimo"},
+		{rank: 15, short: ScaleName{"quindecilhão", "quindecilhões"}, long: ScaleName{"quindecilião", "quindeciliões"}, ordinal: "quindecilionésimo"},
+		{rank: 20, short: ScaleName{"vigintilhão", "vigintilhões"}, long: ScaleName{"vigintilião", "vigintiliões"}, ordinal: "vigintilionésimo"},
+		{rank: 21, short: ScaleName{"unvigintilhão", "unvigintilhões"}, long: ScaleName{"unvigintilião", "unvigintiliões"}, ordinal: "unvigintilionésimo"},
+		{rank: 30, short: ScaleName{"trigintilhão", "trigintilhões"}, long: ScaleName{"trigintilião", "trigintiliões"}, ordinal: "trigintilionésimo"},
+		{rank: 100, short: ScaleName{"centilhão", "centilhões"}, long: ScaleName{"centilião", "centiliões"}, ordinal: "centilionésimo"},
+		{rank: 999, short: ScaleName{"novennonagintanongentilhão", "novennonagintanongentilhões"}, long: ScaleName{"novennonagintanongentilião", "novennonagintanongentiliões"}, ordinal: "novennonagintanongentilionésimo"},
+	}
+
+	for _, test := range tests {
+		scale, ok := scaleAt(test.rank)
+
+		if !ok || scale.Short != test.short || scale.Long != test.long || scale.Ordinal != test.ordinal {
+			t.Errorf("scaleAt(%d) = %+v, %v, want %v, %v, %v", test.rank, scale, ok, test.short, test.long, test.ordinal)
+		}
+	}
+
+	if _, ok := scaleAt(maxScaleRank + 1); ok {
+		t.Errorf("scaleAt(%d) should have no scale", maxScaleRank+1)
+	}
+}
+
+func TestScalesCompoundRoundTrip(t *testing.T) {
+	for _, locale := range []Locale{PtBR, PtPT} {
+		largest := locale.scaleValue(maxScaleRank)
+
+		for _, multiple := range []string{"1000", "1000000", "1000001", "2000005", "1" + strings.Repeat("0", 3000)} {
+			for _, remainder := range []int64{0, 2, 7000000} {
+				number, _ := new(big.Int).SetString(multiple, 10)
+				number.Mul(number, largest).Add(number, big.NewInt(remainder))
+
+				speller := NewSpeller()
+				speller.SetLocale(locale)
+
+				spelled := speller.Spell(new(big.Int).Set(number))
+
+				lexer := NewLexer(nil)
+				lexer.SetLocale(locale)
+
+				tokens, err := lexer.ParseLine(spelled)
+
+				if err != nil {
+					t.Fatalf("%q: unexpected error: %v", spelled, err)
+				}
+
+				if len(tokens) != 1 || tokens[0].Number == nil || tokens[0].Number.Cmp(number) != 0 {
+					t.Errorf("%q: expected %v, got %v", spelled, number, tokens)
+				}
+			}
+		}
+	}
+}
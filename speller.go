@@ -1,35 +1,151 @@
 package spellnumber
 
 import (
-	"io"
-	"log"
+	"bytes"
+	"maps"
 	"math"
 	"math/big"
-	"os"
+	"strconv"
 	"strings"
 )
 
+// DecimalStyle selects how SpellDecimal reads the decimal part of a number.
+type DecimalStyle int
+
+const (
+	// DecimalComma reads the decimal digits after "virgula", as in "dois
+	// virgula cinco"
+	DecimalComma DecimalStyle = iota
+	// DecimalFraction reads them as a fraction of the unit, as in "dois
+	// inteiros e cinco decimos"
+	DecimalFraction
+)
+
+// Gender is the grammatical gender of the noun a number refers to.
+type Gender int
+
+const (
+	Masculine Gender = iota
+	Feminine
+)
+
 type Speller struct {
-	thousands map[int][]string
-	numbers   map[int]string
-	and       string
-	negative  string
-	hundred   string
-	hundreds  string
-	verbose   bool
+	// table holds the spelling of the triplets and the scale names in the
+	// locale and form set by SetLocale and SetASCII
+	table    *spellTable
+	thousand string
+	numbers  map[int]string
+	// ordinals holds the masculine ordinals of the units, tens and hundreds
+	// and ordinalThousand that of "mil", those of the scale names are in table
+	ordinals        map[int]string
+	ordinalThousand string
+	// indicators abbreviate the ordinals, as in "1º" and "1ª"
+	indicators map[Gender]string
+	// denominators names the fractions of the unit that are not ordinals
+	denominators map[int]string
+	avos         string
+	// currency is the currency SpellCurrency writes, its units following "de"
+	// when the amount ends in a scale name
+	currency     Currency
+	of           string
+	and          string
+	negative     string
+	hundred      string
+	hundreds     string
+	comma        string
+	integer      []string
+	decimalStyle DecimalStyle
+	// feminine holds the feminine forms of the units and hundreds that have one
+	feminine map[int]string
+	gender   Gender
+	locale   Locale
+	ascii    bool
+	verbose  bool
+}
+
+// localeNumbers holds the names of the numbers each locale spells its own way.
+var localeNumbers = map[Locale]map[int]string{
+	PtBR: {
+		14: "quatorze",
+		16: "dezesseis",
+		17: "dezessete",
+		19: "dezenove",
+	},
+	PtPT: {
+		14: "catorze",
+		16: "dezasseis",
+		17: "dezassete",
+		19: "dezanove",
+	},
 }
 
 func NewSpeller() *Speller {
-	return &Speller{
+	speller := &Speller{
 		and:      "e",
 		negative: "menos",
 		hundred:  "cem",
 		hundreds: "cento",
+		comma:    "vírgula",
+		integer:  []string{"inteiro", "inteiros"},
+		avos:     "avos",
+		currency: Currencies["BRL"],
+		of:       "de",
+		denominators: map[int]string{
+			2: "meio",
+			3: "terço",
+		},
+		feminine: map[int]string{
+			1:   "uma",
+			2:   "duas",
+			200: "duzentas",
+			300: "trezentas",
+			400: "quatrocentas",
+			500: "quinhentas",
+			600: "seiscentas",
+			700: "setecentas",
+			800: "oitocentas",
+			900: "novecentas",
+		},
+		indicators: map[Gender]string{
+			Masculine: "º",
+			Feminine:  "ª",
+		},
+		ordinals: map[int]string{
+			1:   "primeiro",
+			2:   "segundo",
+			3:   "terceiro",
+			4:   "quarto",
+			5:   "quinto",
+			6:   "sexto",
+			7:   "sétimo",
+			8:   "oitavo",
+			9:   "nono",
+			10:  "décimo",
+			20:  "vigésimo",
+			30:  "trigésimo",
+			40:  "quadragésimo",
+			50:  "quinquagésimo",
+			60:  "sexagésimo",
+			70:  "septuagésimo",
+			80:  "octogésimo",
+			90:  "nonagésimo",
+			100: "centésimo",
+			200: "ducentésimo",
+			300: "trecentésimo",
+			400: "quadringentésimo",
+			500: "quingentésimo",
+			600: "sexcentésimo",
+			700: "septingentésimo",
+			800: "octingentésimo",
+			900: "nongentésimo",
+		},
+		thousand:        "mil",
+		ordinalThousand: "milésimo",
 		numbers: map[int]string{
 			-1:  "zero",
 			1:   "um",
 			2:   "dois",
-			3:   "tres",
+			3:   "três",
 			4:   "quatro",
 			5:   "cinco",
 			6:   "seis",
@@ -40,12 +156,8 @@ func NewSpeller() *Speller {
 			11:  "onze",
 			12:  "doze",
 			13:  "treze",
-			14:  "quatorze",
 			15:  "quinze",
-			16:  "dezesseis",
-			17:  "dezessete",
 			18:  "dezoito",
-			19:  "dezenove",
 			20:  "vinte",
 			30:  "trinta",
 			40:  "quarenta",
@@ -63,32 +175,58 @@ func NewSpeller() *Speller {
 			800: "oitocentos",
 			900: "novecentos",
 		},
-		thousands: map[int][]string{
-			0:  {"", ""},
-			1:  {"mil", "mil"},
-			2:  {"milhao", "milhoes"},
-			3:  {"bilhao", "bilhoes"},
-			4:  {"trilhao", "trilhoes"},
-			5:  {"quatrilhao", "quatrilhoes"},
-			6:  {"quintilhao", "quintilhoes"},
-			7:  {"sextilhao", "sextilhoes"},
-			8:  {"setilhao", "setilhoes"},
-			9:  {"octilhao", "octilhoes"},
-			10: {"nonilhao", "nonilhoes"},
-			11: {"decilhao", "decilhoes"},
-			12: {"undecilhao", "undecilhoes"},
-			13: {"duodecilhao", "duodecilhoes"},
-			14: {"tredecilhao", "tredecilhoes"},
-			15: {"quatrodecilhao", "quatrodecilhoes"},
-		},
 	}
 
+	speller.SetLocale(PtBR)
+
+	return speller
 }
 
 func (s *Speller) SetVerbose(verbose bool) {
 	s.verbose = verbose
 }
 
+// SetGender selects the gender Spell agrees with, as in "duas mil e uma" for
+// Feminine. Only the units and thousands take it: "milhao" and above are
+// masculine nouns, as in "dois milhoes e duzentas mil".
+func (s *Speller) SetGender(gender Gender) {
+	s.gender = gender
+}
+
+// SetLocale selects the variant of Portuguese spelled: PtBR, the default, or
+// PtPT, as in "dezasseis" and "dois mil milhoes" for 2*10^9.
+func (s *Speller) SetLocale(locale Locale) {
+	s.locale = locale
+
+	// A new map, as the copies of the speller share the one in use
+	s.numbers = maps.Clone(s.numbers)
+
+	for n, word := range localeNumbers[locale] {
+		s.numbers[n] = word
+	}
+
+	s.table = s.sharedTable()
+}
+
+// SetASCII makes the speller write the words without accents, as in "tres
+// milhoes", instead of the default NFC normalised "três milhões".
+func (s *Speller) SetASCII(ascii bool) {
+	s.ascii = ascii
+	s.table = s.sharedTable()
+}
+
+// SetCurrency selects the currency SpellCurrency writes, BRL by default. It
+// can be any of Currencies or one built by hand.
+func (s *Speller) SetCurrency(currency Currency) {
+	s.currency = currency
+}
+
+// SetDecimalStyle selects how SpellDecimal reads the decimal part, after
+// "virgula" (DecimalComma, the default) or as a fraction (DecimalFraction).
+func (s *Speller) SetDecimalStyle(style DecimalStyle) {
+	s.decimalStyle = style
+}
+
 func (s Speller) formatNumberStr(numberStr string) string {
 	length := len(numberStr)
 	rest := length % 3
@@ -108,137 +246,516 @@ func (s Speller) formatNumberStr(numberStr string) string {
 	return builder.String()
 }
 
+// Spell spells number, as in "cento e vinte e três".
 func (s Speller) Spell(number *big.Int) string {
-	if !s.verbose {
-		log.SetOutput(io.Discard)
+	return string(s.AppendSpell(make([]byte, 0, 64), number))
+}
 
-		defer log.SetOutput(os.Stdout)
-	}
+// writeNumber writes the spelling of number to b, one class at a time.
+func (s Speller) writeNumber(b *spellBuffer, number *big.Int) {
+	if number.Sign() == 0 {
+		b.add(s.table.zero)
 
-	negativeSign := ""
+		return
+	}
 
 	if number.Sign() < 0 {
-		negativeSign = s.negative + " "
-
-		number = number.Abs(number)
+		b.add(s.table.negative)
+		b.add(" ")
 	}
 
-	numberStr := number.String()
+	var digits [20]byte
 
-	numberStrLen := len(numberStr)
+	s.writeDigits(b, numberDigits(digits[:0], number))
+}
 
-	// Support until 10^49
-	if numberStrLen > 49 {
-		return numberStr
+// numberDigits appends the digits of the absolute value of number to dst,
+// without allocating for the numbers of 64 bits.
+func numberDigits(dst []byte, number *big.Int) []byte {
+	switch {
+	case number.IsUint64():
+		return strconv.AppendUint(dst, number.Uint64(), 10)
+	case number.IsInt64():
+		return strconv.AppendUint(dst, uint64(-number.Int64()), 10)
 	}
 
-	if numberStr == "0" {
-		return s.numbers[-1]
+	return bytes.TrimPrefix(number.Append(dst, 10), []byte("-"))
+}
+
+// tripletAt returns the value of the class of the given order of digits.
+func tripletAt(digits []byte, order int) int {
+	end := len(digits) - 3*order
+	n := 0
+
+	for _, digit := range digits[max(end-3, 0):end] {
+		n = n*10 + int(digit-'0')
 	}
 
-	formattedNumber := s.formatNumberStr(numberStr)
+	return n
+}
 
-	builder := strings.Builder{}
+// writeDigits writes the spelling of a positive number given by its digits,
+// without leading zeros. The classes of the same rank are read together before
+// its scale name, which is singular only for a rank worth exactly one, as in
+// "um milhao", not in "mil milhoes".
+func (s Speller) writeDigits(b *spellBuffer, digits []byte) {
+	if s.pastScales(len(digits)) {
+		s.writeCompound(b, digits)
 
-	builder.WriteString(negativeSign)
+		return
+	}
 
-	lastOrder := s.lastOrder(formattedNumber)
+	top := (len(digits) - 1) / 3
+	last := 0
 
-	formattedNumberLen := len(formattedNumber)
+	for tripletAt(digits, last) == 0 {
+		last++
+	}
 
-	for i := 0; i < formattedNumberLen; i += 3 {
-		nStr := formattedNumber[i : i+3]
+	for order := top; order >= last; order-- {
+		n := tripletAt(digits, order)
 
-		if nStr == "000" {
+		if n == 0 {
 			continue
 		}
 
-		order := s.order(formattedNumber, i)
-
-		pluralIdx := 0
-
-		if i > 0 {
-			builder.WriteString(" ")
+		if order < top {
+			b.add(" ")
 
-			if lastOrder == order {
-				builder.WriteString(s.and)
-				builder.WriteString(" ")
+			if order == last {
+				b.add(s.table.and)
+				b.add(" ")
 			}
 		}
 
+		rank, thousand := s.rank(order)
+
 		// mil
-		if order == 1 && nStr == "001" {
-			builder.WriteString(s.thousands[order][pluralIdx])
-			continue
-		}
+		if thousand && n == 1 {
+			b.add(s.table.thousand)
+		} else {
+			gender := Masculine
+
+			if rank == 0 {
+				gender = s.gender
+			}
+
+			b.add(s.table.triplets[gender][n])
 
-		hadNumber := false
-		for j := i; j < i+3; j++ {
-			if formattedNumber[j] == '0' {
-				continue
+			if thousand {
+				b.add(" ")
+				b.add(s.table.thousand)
 			}
+		}
+
+		if rank > 0 && !s.sharesRank(digits, order, order-1) {
+			plural := 1
 
-			if j != i && hadNumber {
-				builder.WriteString(" ")
-				builder.WriteString(s.and)
-				builder.WriteString(" ")
+			if n == 1 && !thousand && !s.sharesRank(digits, order, order+1) {
+				plural = 0
 			}
 
-			n := int(formattedNumber[j]-'0') * int(math.Pow10(2-(j-i)))
+			b.add(" ")
+			b.add(s.table.thousands[rank][plural])
+		}
 
-			hadNumber = true
+		b.spill()
+	}
+}
 
-			// dez até dezenove
-			if n == 10 {
-				n = n + int(formattedNumber[j+1]-'0')
+// sharesRank reports whether the class of the given order of digits has a
+// neighbour of another order, not zero, read before the same scale name.
+func (s Speller) sharesRank(digits []byte, order int, other int) bool {
+	if other < 0 || other > (len(digits)-1)/3 {
+		return false
+	}
 
-				j++
-			}
+	rank, _ := s.rank(order)
+	otherRank, _ := s.rank(other)
 
-			if n > 1 {
-				pluralIdx = 1
-			}
+	return rank == otherRank && tripletAt(digits, other) != 0
+}
 
-			if n == 100 {
-				if strings.HasSuffix(nStr, "00") {
-					builder.WriteString(s.hundred)
-					break
-				}
+// pastScales reports whether the highest class of a positive number with the
+// given number of digits is past the largest scale name.
+func (s Speller) pastScales(length int) bool {
+	rank, _ := s.rank((length - 1) / 3)
 
-				builder.WriteString(s.hundreds)
-				continue
-			}
+	return rank > maxScaleRank
+}
+
+// writeCompound writes a number past the largest scale name as a multiple of
+// it, the multiple spelled in full, as in "mil novennonagintanongentilhoes".
+// The multiple is followed by "de" when it has a scale name of its own, as in
+// "um milhao de novennonagintanongentilhoes", so that the lexer reads it back
+// as a whole. The digits are split apart, so that the classes spelled at a
+// time are never more than those below the largest scale name.
+func (s Speller) writeCompound(b *spellBuffer, digits []byte) {
+	split := len(digits) - s.locale.scaleExponent(maxScaleRank)
+	quotient, remainder := digits[:split], bytes.TrimLeft(digits[split:], "0")
+
+	// The scale names are masculine nouns
+	multiple := s
+	multiple.gender = Masculine
+
+	multiple.writeDigits(b, quotient)
+
+	if rank, _ := s.rank((len(quotient) - 1) / 3); rank > 0 {
+		b.add(" ")
+		b.add(s.table.of)
+	}
 
-			builder.WriteString(s.numbers[n])
+	b.add(" ")
+	b.add(s.table.thousands[maxScaleRank][1])
+
+	if len(remainder) == 0 {
+		return
+	}
+
+	b.add(" ")
+
+	// "e" comes before the last class only
+	classes := 0
+
+	for order := range (len(remainder)-1)/3 + 1 {
+		if tripletAt(remainder, order) != 0 {
+			classes++
 		}
+	}
 
-		if order == 0 {
+	if classes == 1 {
+		b.add(s.table.and)
+		b.add(" ")
+	}
+
+	s.writeDigits(b, remainder)
+}
+
+// word returns the name of n, a unit, ten or hundred, in the gender set by
+// SetGender.
+func (s Speller) word(n int) string {
+	if word, ok := s.feminine[n]; ok && s.gender == Feminine {
+		return word
+	}
+
+	return s.numbers[n]
+}
+
+// numberClass is a group of three digits of a number, with its order: 0 for
+// the units, 1 for the thousands, 2 for the millions... The classes of the
+// same rank are read together before its scale name: thousand marks the
+// class followed by "mil", named the last class of its rank, and one a rank
+// worth exactly one, as in "um milhao".
+type numberClass struct {
+	digits   string
+	order    int
+	rank     int
+	thousand bool
+	named    bool
+	one      bool
+}
+
+// classes splits the digits of a number into its classes, from the highest,
+// leaving out the classes that are zero.
+func (s Speller) classes(numberStr string) []numberClass {
+	formattedNumber := s.formatNumberStr(numberStr)
+
+	classes := make([]numberClass, 0, len(formattedNumber)/3)
+
+	for i := 0; i < len(formattedNumber); i += 3 {
+		if formattedNumber[i:i+3] == "000" {
 			continue
 		}
 
+		order := s.order(formattedNumber, i)
+		rank, thousand := s.rank(order)
+
+		classes = append(classes, numberClass{digits: formattedNumber[i : i+3], order: order, rank: rank, thousand: thousand})
+	}
+
+	for i := range classes {
+		first := i == 0 || classes[i-1].rank != classes[i].rank
+		classes[i].named = i == len(classes)-1 || classes[i+1].rank != classes[i].rank
+		classes[i].one = first && classes[i].named && classes[i].rank > 0 && !classes[i].thousand && classes[i].digits == "001"
+	}
+
+	return classes
+}
+
+// rank returns the rank of the scale name read after the class of the given
+// order, and whether "mil" comes before it: the short scale names every
+// order from 2 on, the long scale every even order.
+func (s Speller) rank(order int) (int, bool) {
+	if s.locale == PtPT {
+		return order / 2, order%2 == 1
+	}
+
+	if order < 2 {
+		return 0, order == 1
+	}
+
+	return order - 1, false
+}
+
+// ordinalScale returns the ordinal of 10^(3*order), as in "milionesimo", or
+// "milesimo milionesimo" for the orders read as "mil milhoes".
+func (s Speller) ordinalScale(order int) []string {
+	rank, thousand := s.rank(order)
+
+	words := make([]string, 0, 2)
+
+	if thousand {
+		words = append(words, s.ordinalThousand)
+	}
+
+	if rank > 0 {
+		words = append(words, s.table.ordinalThousands[rank])
+	}
+
+	return words
+}
+
+func (s Speller) order(number string, i int) int {
+	return (len(number) - i - 1) / 3
+}
+
+// SpellDecimal spells number with its decimal part, in the style set by
+// SetDecimalStyle. Numbers without a finite decimal expansion, such as 1/3,
+// are spelled as fractions, by SpellRat.
+func (s Speller) SpellDecimal(number *big.Rat) string {
+	places, ok := decimalPlaces(number)
+
+	if !ok {
+		return s.SpellRat(number)
+	}
+
+	digits := new(big.Rat).Abs(number).FloatString(places)
+
+	integerStr, decimal, _ := strings.Cut(digits, ".")
+	decimal = strings.TrimRight(decimal, "0")
+
+	integer, _ := new(big.Int).SetString(integerStr, 10)
+
+	if decimal == "" {
+		if number.Sign() < 0 {
+			integer.Neg(integer)
+		}
+
+		return s.Spell(integer)
+	}
+
+	// "um inteiro", "um decimo": the parts agree with masculine nouns
+	s.gender = Masculine
+
+	builder := strings.Builder{}
+
+	if number.Sign() < 0 {
+		builder.WriteString(s.negative)
 		builder.WriteString(" ")
-		builder.WriteString(s.thousands[order][pluralIdx])
+	}
+
+	significant := strings.TrimLeft(decimal, "0")
+	fraction, _ := new(big.Int).SetString(significant, 10)
+
+	if s.decimalStyle == DecimalFraction {
+		if integer.Sign() > 0 {
+			builder.WriteString(s.Spell(integer))
+			builder.WriteString(" ")
+			builder.WriteString(s.integer[min(integer.Cmp(big.NewInt(1)), 1)])
+			builder.WriteString(" ")
+			builder.WriteString(s.and)
+			builder.WriteString(" ")
+		}
+
+		builder.WriteString(s.Spell(fraction))
+		builder.WriteString(" ")
+		builder.WriteString(s.fractionName(len(decimal), fraction.Cmp(big.NewInt(1)) == 0))
+
+		return s.output(builder.String())
+	}
+
+	builder.WriteString(s.Spell(integer))
+	builder.WriteString(" ")
+	builder.WriteString(s.comma)
+
+	for range len(decimal) - len(significant) {
+		builder.WriteString(" ")
+		builder.WriteString(s.numbers[-1])
+	}
+
+	builder.WriteString(" ")
+	builder.WriteString(s.Spell(fraction))
+
+	return s.output(builder.String())
+}
+
+// fractionName names the fraction of the unit with the given decimal places:
+// "decimo", "centesimo", "milesimo", then "decimos de milesimo" and so on.
+func (s Speller) fractionName(places int, singular bool) string {
+	plural := "s"
+
+	if singular {
+		plural = ""
+	}
+
+	order, rest := places/3, places%3
+
+	if order == 0 {
+		return s.ordinals[int(math.Pow10(rest))] + plural
+	}
+
+	words := s.ordinalScale(order)
+
+	if rest > 0 {
+		words = append([]string{s.ordinals[int(math.Pow10(rest))]}, words...)
+	}
+
+	if len(words) == 1 {
+		return words[0] + plural
+	}
+
+	return words[0] + plural + " de " + strings.Join(words[1:], " de ")
+}
+
+// decimalPlaces returns the number of decimal places of r, if its decimal
+// expansion is finite: that is, if its denominator has no prime factor but 2
+// and 5.
+func decimalPlaces(r *big.Rat) (int, bool) {
+	denominator := new(big.Int).Set(r.Denom())
+	remainder := new(big.Int)
+
+	twos := denominator.TrailingZeroBits()
+	denominator.Rsh(denominator, twos)
 
-		if order == lastOrder {
+	fives := 0
+
+	for five := big.NewInt(5); ; fives++ {
+		quotient, _ := new(big.Int).QuoRem(denominator, five, remainder)
+
+		if remainder.Sign() != 0 {
 			break
 		}
+
+		denominator = quotient
 	}
 
-	return builder.String()
+	if denominator.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+
+	return max(int(twos), fives), true
 }
 
-func (s Speller) lastOrder(formattedNumber string) int {
-	for i := len(formattedNumber); i >= 0; i -= 3 {
-		nStr := formattedNumber[i-3 : i]
+// SpellRat spells number as a fraction, as in "dois tercos" or "cinco onze
+// avos". Fractions above one are spelled as mixed numbers, as in "um inteiro e
+// um quarto".
+func (s Speller) SpellRat(number *big.Rat) string {
+	if number.IsInt() {
+		return s.Spell(new(big.Int).Set(number.Num()))
+	}
+
+	s.gender = Masculine
+
+	builder := strings.Builder{}
+
+	if number.Sign() < 0 {
+		builder.WriteString(s.negative)
+		builder.WriteString(" ")
+	}
 
-		if nStr != "000" {
-			return s.order(formattedNumber, i-3)
+	integer, numerator := new(big.Int).QuoRem(new(big.Int).Abs(number.Num()), number.Denom(), new(big.Int))
+
+	if integer.Sign() > 0 {
+		builder.WriteString(s.Spell(integer))
+		builder.WriteString(" ")
+		builder.WriteString(s.integer[min(integer.Cmp(big.NewInt(1)), 1)])
+		builder.WriteString(" ")
+		builder.WriteString(s.and)
+		builder.WriteString(" ")
+	}
+
+	builder.WriteString(s.Spell(numerator))
+	builder.WriteString(" ")
+	builder.WriteString(s.denominatorName(number.Denom(), numerator.Cmp(big.NewInt(1)) == 0))
+
+	return s.output(builder.String())
+}
+
+// denominatorName names the fraction of the unit with the given denominator:
+// "meio", "terco", then the ordinals of a single word ("quarto", "vigesimo",
+// "centesimo"), "decimo de milesimo" and so on for powers of ten, and the
+// denominator followed by "avos" otherwise.
+func (s Speller) denominatorName(denominator *big.Int, singular bool) string {
+	plural := "s"
+
+	if singular {
+		plural = ""
+	}
+
+	if name, ok := s.denominators[int(denominator.Int64())]; ok && denominator.IsInt64() {
+		return name + plural
+	}
+
+	if name, ok := s.ordinals[int(denominator.Int64())]; ok && denominator.IsInt64() {
+		return name + plural
+	}
+
+	digits := denominator.String()
+
+	if digits[0] == '1' && strings.Trim(digits[1:], "0") == "" && len(digits)-1 <= maxDecimalPlaces {
+		return s.fractionName(len(digits)-1, singular)
+	}
+
+	return s.Spell(new(big.Int).Set(denominator)) + " " + s.avos
+}
+
+// SpellOrdinal spells the ordinal of number in the given gender, as in
+// "ducentesimo quadragesimo primeiro" or "milionesima". Ordinals only exist for
+// positive numbers: the others, and those past the largest scale name, from
+// 10^3003 in PtBR and 10^6000 in PtPT, are written with digits, as in "0º".
+func (s Speller) SpellOrdinal(number *big.Int, gender Gender) string {
+	numberStr := number.String()
+
+	// Past the largest scale name, which has no multiples among the ordinals
+	if number.Sign() <= 0 || s.pastScales(len(numberStr)) {
+		return numberStr + s.indicators[gender]
+	}
+
+	words := make([]string, 0, 8)
+
+	for _, class := range s.classes(numberStr) {
+		// "milesimo", not "primeiro milesimo"
+		if class.digits != "001" || !class.thousand && !class.one {
+			for i, digit := range class.digits {
+				if digit != '0' {
+					words = append(words, s.ordinals[int(digit-'0')*int(math.Pow10(2-i))])
+				}
+			}
+		}
+
+		if class.thousand {
+			words = append(words, s.ordinalThousand)
+		}
+
+		if class.named && class.rank > 0 {
+			words = append(words, s.table.ordinalThousands[class.rank])
 		}
 	}
 
-	return 0
+	if gender == Feminine {
+		for i, word := range words {
+			words[i] = strings.TrimSuffix(word, "o") + "a"
+		}
+	}
+
+	return s.output(strings.Join(words, " "))
 }
 
-func (s Speller) order(number string, i int) int {
-	return (len(number) - i - 1) / 3
+// output returns the spelling of a number in the form set by SetASCII.
+func (s Speller) output(spelled string) string {
+	if s.ascii {
+		return removeAccents(spelled)
+	}
+
+	return spelled
 }
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"math/big"
+	"math/rand"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 func TestSpellerSpell(t *testing.T) {
@@ -60,18 +64,403 @@ func TestSpellerSpell(t *testing.T) {
 		{
 			name:     "Large number",
 			input:    big.NewInt(123456789),
-			expected: "cento e vinte e tres milhoes quatrocentos e cinquenta e seis mil e setecentos e oitenta e nove",
+			expected: "cento e vinte e três milhões quatrocentos e cinquenta e seis mil e setecentos e oitenta e nove",
+		},
+		{
+			name:     "Vigintilhao",
+			input:    new(big.Int).Exp(big.NewInt(10), big.NewInt(63), nil),
+			expected: "um vigintilhão",
+		},
+		{
+			name:     "Centilhoes",
+			input:    new(big.Int).Mul(big.NewInt(2), new(big.Int).Exp(big.NewInt(10), big.NewInt(303), nil)),
+			expected: "dois centilhões",
+		},
+		{
+			name:     "Mil past the largest scale",
+			input:    new(big.Int).Exp(big.NewInt(10), big.NewInt(3003), nil),
+			expected: "mil novennonagintanongentilhões",
+		},
+		{
+			name:     "Largest scale of largest scale",
+			input:    new(big.Int).Add(new(big.Int).Exp(big.NewInt(10), big.NewInt(6000), nil), big.NewInt(7)),
+			expected: "um novennonagintanongentilhão de novennonagintanongentilhões e sete",
 		},
 		{
 			name:     "Stupendous number",
 			input:    big.NewInt(1).MulRange(1, 100),
-			expected: big.NewInt(1).MulRange(1, 100).String(),
+			expected: "noventa e três unquinquagintilhões trezentos e vinte e seis quinquagintilhões duzentos e quinze novenquadragintilhões quatrocentos e quarenta e três octoquadragintilhões novecentos e quarenta e quatro septenquadragintilhões cento e cinquenta e dois sexquadragintilhões seiscentos e oitenta e um quinquadragintilhões seiscentos e noventa e nove quatuorquadragintilhões duzentos e trinta e oito tresquadragintilhões oitocentos e cinquenta e seis duoquadragintilhões duzentos e sessenta e seis unquadragintilhões setecentos quadragintilhões quatrocentos e noventa noventrigintilhões setecentos e quinze octotrigintilhões novecentos e sessenta e oito septentrigintilhões duzentos e sessenta e quatro sextrigintilhões trezentos e oitenta e um quintrigintilhões seiscentos e vinte e um quatuortrigintilhões quatrocentos e sessenta e oito trestrigintilhões quinhentos e noventa e dois duotrigintilhões novecentos e sessenta e três untrigintilhões oitocentos e noventa e cinco trigintilhões duzentos e dezessete novenvigintilhões quinhentos e noventa e nove octovigintilhões novecentos e noventa e três septenvigintilhões duzentos e vinte e nove sexvigintilhões novecentos e quinze quinvigintilhões seiscentos e oito quatuorvigintilhões novecentos e quarenta e um tresvigintilhões quatrocentos e sessenta e três duovigintilhões novecentos e setenta e seis unvigintilhões cento e cinquenta e seis vigintilhões quinhentos e dezoito novendecilhões duzentos e oitenta e seis octodecilhões duzentos e cinquenta e três septendecilhões seiscentos e noventa e sete sexdecilhões novecentos e vinte quindecilhões oitocentos e vinte e sete quatrodecilhões duzentos e vinte e três tredecilhões setecentos e cinquenta e oito duodecilhões duzentos e cinquenta e um undecilhões cento e oitenta e cinco decilhões duzentos e dez nonilhões novecentos e dezesseis octilhões e oitocentos e sessenta e quatro setilhões",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			speller := NewSpeller()
+			result := speller.Spell(test.input)
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellDecimal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *big.Rat
+		style    DecimalStyle
+		expected string
+	}{
+		{
+			name:     "Integer",
+			input:    big.NewRat(10, 1),
+			expected: "dez",
+		},
+		{
+			name:     "Virgula",
+			input:    big.NewRat(5, 2),
+			expected: "dois vírgula cinco",
+		},
+		{
+			name:     "Virgula com zeros",
+			input:    big.NewRat(3005, 1000),
+			expected: "três vírgula zero zero cinco",
+		},
+		{
+			name:     "Virgula negativo",
+			input:    big.NewRat(-1, 4),
+			expected: "menos zero vírgula vinte e cinco",
+		},
+		{
+			name:     "Fracao",
+			input:    big.NewRat(5, 2),
+			style:    DecimalFraction,
+			expected: "dois inteiros e cinco décimos",
+		},
+		{
+			name:     "Fracao singular",
+			input:    big.NewRat(101, 100),
+			style:    DecimalFraction,
+			expected: "um inteiro e um centésimo",
+		},
+		{
+			name:     "Fracao sem inteiro",
+			input:    big.NewRat(25, 10000),
+			style:    DecimalFraction,
+			expected: "vinte e cinco décimos de milésimo",
+		},
+		{
+			name:     "Fracao de milionesimo",
+			input:    big.NewRat(3, 1000000),
+			style:    DecimalFraction,
+			expected: "três milionésimos",
+		},
+		{
+			name:     "Dizima",
+			input:    big.NewRat(2, 3),
+			expected: "dois terços",
+		},
+		{
+			name:     "Dizima com inteiro",
+			input:    big.NewRat(-7, 3),
+			style:    DecimalFraction,
+			expected: "menos dois inteiros e um terço",
+		},
+		{
+			name:     "Mais de vinte casas",
+			input:    big.NewRat(1, 1<<21),
+			expected: "zero vírgula zero zero zero zero zero zero quatrocentos e setenta e seis trilhões oitocentos e trinta e sete bilhões cento e cinquenta e oito milhões duzentos e três mil e cento e vinte e cinco",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			speller := NewSpeller()
+			speller.SetDecimalStyle(test.style)
+
+			result := speller.SpellDecimal(test.input)
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellRat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *big.Rat
+		expected string
+	}{
+		{
+			name:     "Integer",
+			input:    big.NewRat(6, 2),
+			expected: "três",
+		},
+		{
+			name:     "Tercos",
+			input:    big.NewRat(2, 3),
+			expected: "dois terços",
+		},
+		{
+			name:     "Meio",
+			input:    big.NewRat(1, 2),
+			expected: "um meio",
+		},
+		{
+			name:     "Avos",
+			input:    big.NewRat(5, 11),
+			expected: "cinco onze avos",
+		},
+		{
+			name:     "Centesimos",
+			input:    big.NewRat(3, 100),
+			expected: "três centésimos",
+		},
+		{
+			name:     "Decimos de milesimo",
+			input:    big.NewRat(1, 10000),
+			expected: "um décimo de milésimo",
+		},
+		{
+			name:     "Vigesimos",
+			input:    big.NewRat(3, 20),
+			expected: "três vigésimos",
+		},
+		{
+			name:     "Numero misto",
+			input:    big.NewRat(5, 4),
+			expected: "um inteiro e um quarto",
+		},
+		{
+			name:     "Numero misto negativo",
+			input:    big.NewRat(-17, 3),
+			expected: "menos cinco inteiros e dois terços",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			speller := NewSpeller()
+			result := speller.SpellRat(test.input)
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellOrdinal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *big.Int
+		gender   Gender
+		expected string
+	}{
+		{
+			name:     "Primeiro",
+			input:    big.NewInt(1),
+			expected: "primeiro",
+		},
+		{
+			name:     "Primeira",
+			input:    big.NewInt(1),
+			gender:   Feminine,
+			expected: "primeira",
+		},
+		{
+			name:     "Decimo primeiro",
+			input:    big.NewInt(11),
+			expected: "décimo primeiro",
+		},
+		{
+			name:     "Vigesimo terceiro",
+			input:    big.NewInt(23),
+			expected: "vigésimo terceiro",
+		},
+		{
+			name:     "Ducentesimo quadragesimo primeiro",
+			input:    big.NewInt(241),
+			expected: "ducentésimo quadragésimo primeiro",
+		},
+		{
+			name:     "Milesimo",
+			input:    big.NewInt(1000),
+			expected: "milésimo",
+		},
+		{
+			name:     "Segunda milesima vigesima",
+			input:    big.NewInt(2020),
+			gender:   Feminine,
+			expected: "segunda milésima vigésima",
+		},
+		{
+			name:     "Milionesimo",
+			input:    big.NewInt(1000000),
+			expected: "milionésimo",
+		},
+		{
+			name:     "Vigintilionesima",
+			input:    new(big.Int).Exp(big.NewInt(10), big.NewInt(63), nil),
+			gender:   Feminine,
+			expected: "vigintilionésima",
+		},
+		{
+			name:     "Past the largest scale",
+			input:    new(big.Int).Exp(big.NewInt(10), big.NewInt(3003), nil),
+			expected: "1" + strings.Repeat("0", 3003) + "º",
+		},
+		{
+			name:     "Centesimo milesimo",
+			input:    big.NewInt(100000),
+			expected: "centésimo milésimo",
+		},
+		{
+			name:     "Zero",
+			input:    big.NewInt(0),
+			gender:   Feminine,
+			expected: "0ª",
+		},
+		{
+			name:     "Large ordinal",
+			input:    new(big.Int).Exp(big.NewInt(10), big.NewInt(45), nil),
+			expected: "quatrodecilionésimo",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			speller := NewSpeller()
+			result := speller.SpellOrdinal(test.input, test.gender)
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellOrdinalLimit(t *testing.T) {
+	for _, test := range []struct {
+		locale Locale
+		digits int64
+	}{
+		{locale: PtBR, digits: 3003},
+		{locale: PtPT, digits: 6000},
+	} {
+		speller := NewSpeller()
+		speller.SetLocale(test.locale)
+
+		largest := new(big.Int).Exp(big.NewInt(10), big.NewInt(test.digits-1), nil)
+
+		if spelled := speller.SpellOrdinal(largest, Masculine); strings.HasSuffix(spelled, "º") {
+			t.Errorf("10^%d: expected words, got %q", test.digits-1, spelled)
+		}
+
+		past := new(big.Int).Mul(largest, big.NewInt(10))
+
+		if spelled := speller.SpellOrdinal(past, Masculine); spelled != past.String()+"º" {
+			t.Errorf("10^%d: expected digits, got %q", test.digits, spelled)
+		}
+	}
+}
+
+func TestSpellerSpellOrdinalRoundTrip(t *testing.T) {
+	numbers := []*big.Int{
+		big.NewInt(1),
+		big.NewInt(1001),
+		big.NewInt(1001000),
+		big.NewInt(2001000),
+		big.NewInt(1000000001000),
+		big.NewInt(1000001001001),
+		big.NewInt(1000000000),
+		big.NewInt(123456789012),
+	}
+
+	random := rand.New(rand.NewSource(1))
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)
+
+	for range 500 {
+		number := new(big.Int).Rand(random, limit)
+		numbers = append(numbers, number.Add(number, big.NewInt(1)))
+	}
+
+	for _, locale := range []Locale{PtBR, PtPT} {
+		speller := NewSpeller()
+		speller.SetLocale(locale)
+
+		lexer := NewLexer(nil)
+		lexer.SetLocale(locale)
+
+		for _, number := range numbers {
+			for _, gender := range []Gender{Masculine, Feminine} {
+				spelled := speller.SpellOrdinal(number, gender)
+
+				tokens, err := lexer.ParseLine(spelled)
+
+				if err != nil {
+					t.Fatalf("%q: unexpected error: %v", spelled, err)
+				}
+
+				if len(tokens) != 1 || tokens[0].Number == nil || tokens[0].Number.Cmp(number) != 0 {
+					t.Errorf("%q: expected %v, got %v", spelled, number, tokens)
+				}
+			}
+		}
+	}
+}
+
+func TestSpellerSpellFeminine(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *big.Int
+		expected string
+	}{
+		{
+			name:     "Uma",
+			input:    big.NewInt(1),
+			expected: "uma",
+		},
+		{
+			name:     "Vinte e uma",
+			input:    big.NewInt(21),
+			expected: "vinte e uma",
+		},
+		{
+			name:     "Trezentas e uma",
+			input:    big.NewInt(301),
+			expected: "trezentas e uma",
+		},
+		{
+			name:     "Duas mil",
+			input:    big.NewInt(2000),
+			expected: "duas mil",
+		},
+		{
+			name:     "Milhoes are masculine",
+			input:    big.NewInt(2200000),
+			expected: "dois milhões e duzentas mil",
+		},
+		{
+			name:     "Um milhao e uma",
+			input:    big.NewInt(1000001),
+			expected: "um milhão e uma",
+		},
+		{
+			name:     "Mixed genders",
+			input:    big.NewInt(202202202),
+			expected: "duzentos e dois milhões duzentas e duas mil e duzentas e duas",
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			speller := NewSpeller()
+			speller.SetGender(Feminine)
+
 			result := speller.Spell(test.input)
 
 			if result != test.expected {
@@ -80,3 +469,150 @@ func TestSpellerSpell(t *testing.T) {
 		})
 	}
 }
+
+func TestSpellerSpellLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		locale   Locale
+		expected string
+	}{
+		{
+			name:     "Dezesseis",
+			input:    "16",
+			locale:   PtBR,
+			expected: "dezesseis",
+		},
+		{
+			name:     "Dezasseis",
+			input:    "16",
+			locale:   PtPT,
+			expected: "dezasseis",
+		},
+		{
+			name:     "Catorze",
+			input:    "14",
+			locale:   PtPT,
+			expected: "catorze",
+		},
+		{
+			name:     "Dezanove mil",
+			input:    "19017",
+			locale:   PtPT,
+			expected: "dezanove mil e dezassete",
+		},
+		{
+			name:     "Um milhao",
+			input:    "1000000",
+			locale:   PtPT,
+			expected: "um milhão",
+		},
+		{
+			name:     "Mil milhoes",
+			input:    "1000000000",
+			locale:   PtPT,
+			expected: "mil milhões",
+		},
+		{
+			name:     "Dois mil e quinhentos milhoes",
+			input:    "2500000000",
+			locale:   PtPT,
+			expected: "dois mil e quinhentos milhões",
+		},
+		{
+			name:     "Um biliao",
+			input:    "1000000000000",
+			locale:   PtPT,
+			expected: "um bilião",
+		},
+		{
+			name:     "Um trilhao",
+			input:    "1000000000000",
+			locale:   PtBR,
+			expected: "um trilhão",
+		},
+		{
+			name:     "Bilioes e mil milhoes",
+			input:    "3000001000000000",
+			locale:   PtPT,
+			expected: "três mil biliões e mil milhões",
+		},
+		{
+			name:     "Octilioes",
+			input:    "2000000000000000000000000000000000000000000000000",
+			locale:   PtPT,
+			expected: "dois octiliões",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input, _ := new(big.Int).SetString(test.input, 10)
+
+			speller := NewSpeller()
+			speller.SetLocale(test.locale)
+
+			result := speller.Spell(input)
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellASCII(t *testing.T) {
+	tests := []struct {
+		name     string
+		spell    func(s *Speller) string
+		expected string
+	}{
+		{
+			name:     "Spell",
+			spell:    func(s *Speller) string { return s.Spell(big.NewInt(3000003)) },
+			expected: "tres milhoes e tres",
+		},
+		{
+			name:     "SpellDecimal",
+			spell:    func(s *Speller) string { return s.SpellDecimal(big.NewRat(5, 2)) },
+			expected: "dois virgula cinco",
+		},
+		{
+			name:     "SpellRat",
+			spell:    func(s *Speller) string { return s.SpellRat(big.NewRat(2, 3)) },
+			expected: "dois tercos",
+		},
+		{
+			name:     "SpellOrdinal",
+			spell:    func(s *Speller) string { return s.SpellOrdinal(big.NewInt(1000000), Feminine) },
+			expected: "milionesima",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			speller := NewSpeller()
+			speller.SetASCII(true)
+
+			result := test.spell(speller)
+
+			if result != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellIsNFC(t *testing.T) {
+	speller := NewSpeller()
+
+	for _, input := range []string{"3", "1000000", "2000000000", "7", "1000000000000000000"} {
+		number, _ := new(big.Int).SetString(input, 10)
+
+		for _, result := range []string{speller.Spell(number), speller.SpellOrdinal(number, Masculine)} {
+			if !norm.NFC.IsNormalString(result) {
+				t.Errorf("expected %q to be NFC normalised", result)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package spellnumber
+
+import "strings"
+
+// spellTable holds what the speller writes integers with: the spelling of
+// every triplet in both genders and the scale names, in the locale and form of
+// the speller, so that spelling a class is looking it up. The tables are
+// shared by every Speller, which never change them: the setters swap the one
+// in use, so Spellers can be used from several goroutines.
+type spellTable struct {
+	// triplets holds the spelling of the triplets from 1 to 999 by gender, as
+	// in "duzentas e uma"
+	triplets [2][1000]string
+	// thousands holds the scale names by rank, singular and plural, from 1
+	// for "milhao" up to maxScaleRank
+	thousands [maxScaleRank + 1][2]string
+	// ordinalThousands holds the masculine ordinals of the scale names by
+	// rank, as in Scales
+	ordinalThousands [maxScaleRank + 1]string
+	zero             string
+	thousand         string
+	and              string
+	of               string
+	negative         string
+}
+
+// spellTables holds the table of each locale, with accents and in ASCII
+// mode. Each is built once and shared, and built again when Scales is edited.
+var spellTables [PtPT + 1][2]scalesCache[*spellTable]
+
+// sharedTable returns the table of the locale and form of the speller.
+func (s Speller) sharedTable() *spellTable {
+	form := 0
+
+	if s.ascii {
+		form = 1
+	}
+
+	return spellTables[s.locale][form].get(s.newTable)
+}
+
+// newTable builds the table of the speller, without accents in ASCII mode.
+// Besides the locale and the form, it only holds words all spellers share.
+func (s Speller) newTable() *spellTable {
+	table := &spellTable{}
+
+	for _, gender := range []Gender{Masculine, Feminine} {
+		speller := s
+		speller.gender = gender
+
+		for n := 1; n < 1000; n++ {
+			table.triplets[gender][n] = s.output(speller.triplet(n))
+		}
+	}
+
+	for rank := 1; rank <= maxScaleRank; rank++ {
+		if scale, ok := scaleAt(rank); ok {
+			name := s.locale.scaleName(scale)
+			table.thousands[rank] = [2]string{s.output(name.Singular), s.output(name.Plural)}
+			table.ordinalThousands[rank] = scale.Ordinal
+		}
+	}
+
+	table.zero = s.output(s.numbers[-1])
+	table.thousand = s.output(s.thousand)
+	table.and = s.output(s.and)
+	table.of = s.output(s.of)
+	table.negative = s.output(s.negative)
+
+	return table
+}
+
+// triplet spells n, from 1 to 999, in the gender set by SetGender, as in
+// "cento e vinte e tres".
+func (s Speller) triplet(n int) string {
+	words := make([]string, 0, 3)
+
+	hundreds, rest := n/100*100, n%100
+
+	switch {
+	case n == 100:
+		words = append(words, s.hundred)
+	case hundreds == 100:
+		words = append(words, s.hundreds)
+	case hundreds > 0:
+		words = append(words, s.word(hundreds))
+	}
+
+	// dez até dezenove
+	if rest >= 10 && rest < 20 {
+		return strings.Join(append(words, s.word(rest)), " "+s.and+" ")
+	}
+
+	if rest >= 20 {
+		words = append(words, s.word(rest/10*10))
+	}
+
+	if rest%10 > 0 {
+		words = append(words, s.word(rest%10))
+	}
+
+	return strings.Join(words, " "+s.and+" ")
+}
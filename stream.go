@@ -0,0 +1,62 @@
+package spellnumber
+
+import (
+	"io"
+	"math/big"
+)
+
+// spillSize is the size past which SpellTo writes the spelling buffered.
+const spillSize = 4096
+
+// SpellTo writes the spelling of number to w, the same Spell returns, one
+// class at a time: besides the digits of number, the memory it takes stays
+// bounded however long the spelling, as for "fatorial de cem mil". It returns
+// the first error of w.
+func (s Speller) SpellTo(w io.Writer, number *big.Int) error {
+	b := spellBuffer{buf: make([]byte, 0, 2*spillSize), w: w}
+
+	s.writeNumber(&b, number)
+	b.flush()
+
+	return b.err
+}
+
+// AppendSpell appends the spelling of number to dst, the same Spell returns,
+// and returns the extended buffer. It does not allocate for the numbers of 64
+// bits once dst has room for their spelling.
+func (s Speller) AppendSpell(dst []byte, number *big.Int) []byte {
+	b := spellBuffer{buf: dst}
+
+	s.writeNumber(&b, number)
+
+	return b.buf
+}
+
+// spellBuffer holds the spelling being written. When w is set, spill writes
+// it to w once it grows past spillSize, so that streaming takes bounded
+// memory. The first error of w is kept, after which nothing is written.
+type spellBuffer struct {
+	buf []byte
+	w   io.Writer
+	err error
+}
+
+func (b *spellBuffer) add(str string) {
+	b.buf = append(b.buf, str...)
+}
+
+// spill writes the buffer to w if it has grown past spillSize.
+func (b *spellBuffer) spill() {
+	if b.w != nil && len(b.buf) >= spillSize {
+		b.flush()
+	}
+}
+
+// flush writes the buffer to w and empties it.
+func (b *spellBuffer) flush() {
+	if b.err == nil {
+		_, b.err = b.w.Write(b.buf)
+	}
+
+	b.buf = b.buf[:0]
+}
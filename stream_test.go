@@ -0,0 +1,210 @@
+package spellnumber
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestSpellerSpellTo(t *testing.T) {
+	large, _ := new(big.Int).SetString("7"+strings.Repeat("0", 3005)+"42", 10)
+
+	tests := []struct {
+		name   string
+		input  *big.Int
+		config func(*Speller)
+	}{
+		{name: "Zero", input: big.NewInt(0)},
+		{name: "Negative", input: big.NewInt(-1234)},
+		{name: "Feminine", input: big.NewInt(2201), config: func(s *Speller) { s.SetGender(Feminine) }},
+		{name: "ASCII", input: big.NewInt(3016000), config: func(s *Speller) { s.SetASCII(true) }},
+		{name: "Locale", input: big.NewInt(2000000000), config: func(s *Speller) { s.SetLocale(PtPT) }},
+		{name: "Factorial", input: new(big.Int).MulRange(1, 100)},
+		{name: "Past the largest scale", input: large},
+		{name: "Past the largest scale in pt-PT", input: large, config: func(s *Speller) { s.SetLocale(PtPT) }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			speller := NewSpeller()
+
+			if test.config != nil {
+				test.config(speller)
+			}
+
+			expected := speller.Spell(new(big.Int).Set(test.input))
+
+			buffer := bytes.Buffer{}
+
+			if err := speller.SpellTo(&buffer, test.input); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if buffer.String() != expected {
+				t.Errorf("SpellTo: expected %v, got %v", expected, buffer.String())
+			}
+
+			if actual := string(speller.AppendSpell([]byte("= "), test.input)); actual != "= "+expected {
+				t.Errorf("AppendSpell: expected %v, got %v", "= "+expected, actual)
+			}
+		})
+	}
+}
+
+func TestSpellerSpellToLargeFactorial(t *testing.T) {
+	number := new(big.Int).MulRange(1, 10000)
+	speller := NewSpeller()
+
+	counter := &countingWriter{}
+
+	if err := speller.SpellTo(counter, number); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := len(speller.Spell(number)); counter.n != expected {
+		t.Errorf("expected %d bytes, got %d", expected, counter.n)
+	}
+}
+
+var errWrite = errors.New("write failed")
+
+func TestSpellerSpellToError(t *testing.T) {
+	number := new(big.Int).MulRange(1, 1000)
+
+	if err := NewSpeller().SpellTo(failingWriter{}, number); !errors.Is(err, errWrite) {
+		t.Errorf("expected %v, got %v", errWrite, err)
+	}
+}
+
+type countingWriter struct {
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += len(p)
+
+	return len(p), nil
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestSpellerAppendSpellAllocations(t *testing.T) {
+	speller := NewSpeller()
+	number := big.NewInt(-9223372036854775807)
+	dst := make([]byte, 0, 1024)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		dst = speller.AppendSpell(dst[:0], number)
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestNewSpellerSharesTables(t *testing.T) {
+	NewSpeller()
+
+	// The tables are built by the first speller only
+	allocs := testing.AllocsPerRun(10, func() {
+		speller := NewSpeller()
+		speller.SetLocale(PtPT)
+		speller.SetASCII(true)
+	})
+
+	if allocs > 100 {
+		t.Errorf("expected the tables to be shared, got %v allocations", allocs)
+	}
+}
+
+func TestSpellerConcurrent(t *testing.T) {
+	speller := NewSpeller()
+	speller.SetGender(Feminine)
+
+	expected := speller.Spell(big.NewInt(1234567))
+
+	done := make(chan string)
+
+	for range 8 {
+		go func() {
+			copied := *speller
+			copied.SetLocale(PtPT)
+			copied.SetASCII(true)
+			copied.Spell(big.NewInt(1234567))
+
+			done <- speller.Spell(big.NewInt(1234567))
+		}()
+	}
+
+	for range 8 {
+		if actual := <-done; actual != expected {
+			t.Errorf("expected %v, got %v", expected, actual)
+		}
+	}
+}
+
+func BenchmarkNewSpeller(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		speller := NewSpeller()
+		speller.SetLocale(PtBR)
+		speller.SetASCII(false)
+	}
+}
+
+func BenchmarkSpellerAppendSpell(b *testing.B) {
+	speller := NewSpeller()
+	numbers := []*big.Int{big.NewInt(7), big.NewInt(1234), big.NewInt(123456789), big.NewInt(9223372036854775807)}
+	dst := make([]byte, 0, 1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		dst = speller.AppendSpell(dst[:0], numbers[i%len(numbers)])
+	}
+}
+
+func BenchmarkSpellerSpell(b *testing.B) {
+	speller := NewSpeller()
+	number := big.NewInt(123456789)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		speller.Spell(number)
+	}
+}
+
+func BenchmarkSpellerSpellCurrency(b *testing.B) {
+	speller := NewSpeller()
+	amount := big.NewRat(123456789, 100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		speller.SpellCurrency(amount)
+	}
+}
+
+func BenchmarkSpellerAppendSpellLarge(b *testing.B) {
+	speller := NewSpeller()
+	number := new(big.Int).MulRange(1, 1000)
+	dst := make([]byte, 0, 1<<16)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		dst = speller.AppendSpell(dst[:0], number)
+	}
+}
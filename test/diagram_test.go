@@ -0,0 +1,46 @@
+package test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/josecleiton/spellnumber"
+)
+
+// The diagrams in the repository must be regenerated on every grammar change
+func TestDiagramsUpToDate(t *testing.T) {
+	lexer := spellnumber.NewLexer(nil)
+
+	mermaid := strings.Builder{}
+
+	if err := lexer.WriteMermaid(&mermaid); err != nil {
+		t.Fatalf("Mermaid Error: %v\n", err)
+	}
+
+	readme, err := os.ReadFile("../README.md")
+
+	if err != nil {
+		t.Fatalf("README Error: %v\n", err)
+	}
+
+	if !strings.Contains(string(readme), "```mermaid\n"+mermaid.String()+"```") {
+		t.Errorf("README diagram is stale, regenerate it with: cd cmd && go run . -diagram mermaid")
+	}
+
+	dot := strings.Builder{}
+
+	if err := lexer.WriteDOT(&dot); err != nil {
+		t.Fatalf("DOT Error: %v\n", err)
+	}
+
+	file, err := os.ReadFile("../docs/lexer.dot")
+
+	if err != nil {
+		t.Fatalf("DOT file Error: %v\n", err)
+	}
+
+	if string(file) != dot.String() {
+		t.Errorf("docs/lexer.dot is stale, regenerate it with: cd cmd && go run . -diagram dot > ../docs/lexer.dot")
+	}
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/josecleiton/spellnumber"
@@ -22,15 +23,15 @@ func TestExam(t *testing.T) {
 		},
 		{
 			input:    "abre parentese treze mais cinco vezes abre parentese cinco menos abre parentese um mais sete fecha parentese fecha parentese vezes quatro fecha parentese mais um decilhao vezes trinta e um mais um sextilhao vezes fatorial de cinco",
-			expected: "trinta e um decilhoes cento e dezenove sextilhoes novecentos e noventa e nove quintilhoes novecentos e noventa e nove quatrilhoes novecentos e noventa e nove trilhoes novecentos e noventa e nove bilhoes novecentos e noventa e nove milhoes novecentos e noventa e nove mil e novecentos e cinquenta e tres",
+			expected: "trinta e um decilhões cento e dezenove sextilhões novecentos e noventa e nove quintilhões novecentos e noventa e nove quatrilhões novecentos e noventa e nove trilhões novecentos e noventa e nove bilhões novecentos e noventa e nove milhões novecentos e noventa e nove mil e novecentos e cinquenta e três",
 		},
 		{
 			input:    "abre parentese trezentos setilhoes mais quatro trilhoes fecha parentese vezes oito",
-			expected: "dois octilhoes quatrocentos setilhoes e trinta e dois trilhoes",
+			expected: "dois octilhões quatrocentos setilhões e trinta e dois trilhões",
 		},
 		{
 			input:    "fatorial de trinta",
-			expected: "duzentos e sessenta e cinco nonilhoes duzentos e cinquenta e dois octilhoes oitocentos e cinquenta e nove setilhoes oitocentos e doze sextilhoes cento e noventa e um quintilhoes cinquenta e oito quatrilhoes seiscentos e trinta e seis trilhoes trezentos e oito bilhoes e quatrocentos e oitenta milhoes",
+			expected: "duzentos e sessenta e cinco nonilhões duzentos e cinquenta e dois octilhões oitocentos e cinquenta e nove setilhões oitocentos e doze sextilhões cento e noventa e um quintilhões cinquenta e oito quatrilhões seiscentos e trinta e seis trilhões trezentos e oito bilhões e quatrocentos e oitenta milhões",
 		},
 		{
 			input:    "um milhao trezentos e cinquenta e sete mil novecentos e sessenta e tres dividido por cinco mil setecentos e oitenta e nove",
@@ -38,32 +39,56 @@ func TestExam(t *testing.T) {
 		},
 		{
 			input:    "fatorial de trinta vezes abre parentese fatorial de quarenta vezes abre parentese fatorial de oito mais quatro decilhoes fecha parentese fecha parentese",
-			expected: "865695448983915109733797736765501176117285968845982834642736397897194603293761850826838779310899200000000000000000",
+			expected: "oitocentos e sessenta e cinco sextrigintilhões seiscentos e noventa e cinco quintrigintilhões quatrocentos e quarenta e oito quatuortrigintilhões novecentos e oitenta e três trestrigintilhões novecentos e quinze duotrigintilhões cento e nove untrigintilhões setecentos e trinta e três trigintilhões setecentos e noventa e sete novenvigintilhões setecentos e trinta e seis octovigintilhões setecentos e sessenta e cinco septenvigintilhões quinhentos e um sexvigintilhões cento e setenta e seis quinvigintilhões cento e dezessete quatuorvigintilhões duzentos e oitenta e cinco tresvigintilhões novecentos e sessenta e oito duovigintilhões oitocentos e quarenta e cinco unvigintilhões novecentos e oitenta e dois vigintilhões oitocentos e trinta e quatro novendecilhões seiscentos e quarenta e dois octodecilhões setecentos e trinta e seis septendecilhões trezentos e noventa e sete sexdecilhões oitocentos e noventa e sete quindecilhões cento e noventa e quatro quatrodecilhões seiscentos e três tredecilhões duzentos e noventa e três duodecilhões setecentos e sessenta e um undecilhões oitocentos e cinquenta decilhões oitocentos e vinte e seis nonilhões oitocentos e trinta e oito octilhões setecentos e setenta e nove setilhões trezentos e dez sextilhões oitocentos e noventa e nove quintilhões e duzentos quatrilhões",
 		},
 		{
 			input:    "duzentos elevado por dez",
-			expected: "cento e dois sextilhoes e quatrocentos quintilhoes",
+			expected: "cento e dois sextilhões e quatrocentos quintilhões",
 		},
 		{
 			input:    "trezentos e cinquenta e quatro mil setecentos e oitenta e nove dividido por trezentos e cinquenta e sete",
-			expected: "novecentos e noventa e tres",
+			expected: "novecentos e noventa e três",
 		},
 		{
 			input:    "vinte mil",
 			expected: "vinte mil",
 		},
+		{
+			input:    "doze ao quadrado multiplicado por dois sobre tres",
+			expected: "noventa e seis",
+		},
+		{
+			input:    "dois vírgula cinco vezes quatro mais um milhão e meio",
+			expected: "um milhão quinhentos mil e dez",
+		},
+		{
+			input:    "três quartos vezes oito mais um dividido por três vezes três",
+			expected: "sete",
+		},
+		{
+			input:    "vigésimo terceiro mais 2º vezes terceira",
+			expected: "vinte e nove",
+		},
+		{
+			input:    "dez reais e cinquenta centavos vezes dois mais um milhão de reais",
+			expected: "um milhão e vinte e um",
+		},
 	}
 
 	for i, exp := range expressions {
 		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
 
-			lexer := spellnumber.NewLexer(nil)
+			lexer := spellnumber.NewLexerFromReader(strings.NewReader(exp.input))
 
-			tokens, err := lexer.ParseLine(exp.input)
+			tokens := []spellnumber.Token{}
 
-			if err != nil {
-				t.Errorf("Lexer Error: %v\n", err)
-				return
+			for line, err := range lexer.Lines() {
+				if err != nil {
+					t.Errorf("Lexer Error: %v\n", err)
+					return
+				}
+
+				tokens = append(tokens, line...)
 			}
 
 			parser := spellnumber.NewParser(tokens)